@@ -0,0 +1,284 @@
+// Command go-sensitive masks sensitive data in files or stdin, or scans
+// files for it.
+//
+//	go-sensitive [flags] [files...]
+//	go-sensitive scan [flags] <paths...>
+//
+// Without files it reads stdin and writes stdout. The input format is taken
+// from --format, the file extension or, for stdin, sniffed from the content.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+)
+
+const usage = `Usage: go-sensitive [flags] [files...]
+       go-sensitive scan [flags] <paths...>
+
+Masks sensitive data in the given files, or stdin when no file is given, and
+writes the result to stdout (or back to the files with --in-place).
+
+Formats: auto, text, json, ndjson, csv, tsv, xml. With auto the format comes
+from the file extension or, for stdin, from the content.
+
+Without --config or --bundle the default patterns, the default sensitive keys
+and key=value detection are used.
+
+Flags:
+`
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) > 0 && args[0] == "scan" {
+		return runScan(args[1:], stdout, stderr)
+	}
+
+	flags := flag.NewFlagSet("go-sensitive", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, usage)
+		flags.PrintDefaults()
+	}
+
+	var bundles stringList
+	format := flags.String("format", "auto", "input format: auto, text, json, ndjson, csv, tsv or xml")
+	configPath := flags.String("config", "", "JSON or YAML rules file")
+	flags.Var(&bundles, "bundle", "pattern bundle to enable, repeatable or comma separated ("+strings.Join(masker.Bundles(), ", ")+")")
+	inPlace := flags.Bool("in-place", false, "overwrite the given files with the masked output")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if !isFormat(*format) {
+		fmt.Fprintf(stderr, "go-sensitive: unknown format %q\n", *format)
+		return 2
+	}
+	if *inPlace && flags.NArg() == 0 {
+		fmt.Fprintln(stderr, "go-sensitive: --in-place needs at least one file")
+		return 2
+	}
+
+	dm, err := buildMasker(*configPath, bundles)
+	if err != nil {
+		fmt.Fprintf(stderr, "go-sensitive: %v\n", err)
+		return 2
+	}
+
+	if flags.NArg() == 0 {
+		if err := maskStream(dm, *format, "", stdin, stdout, stderr); err != nil {
+			fmt.Fprintf(stderr, "go-sensitive: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	status := 0
+	for _, path := range flags.Args() {
+		if *inPlace {
+			err = maskInPlace(dm, *format, path, stderr)
+		} else {
+			err = maskFile(dm, *format, path, stdout, stderr)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "go-sensitive: %s: %v\n", path, err)
+			status = 1
+		}
+	}
+	return status
+}
+
+func buildMasker(configPath string, bundles []string, opts ...masker.Option) (*masker.DefaultMasker, error) {
+	for _, name := range bundles {
+		if _, ok := masker.BundlePatterns(name); !ok {
+			return nil, fmt.Errorf("unknown bundle %q (available: %s)", name, strings.Join(masker.Bundles(), ", "))
+		}
+	}
+
+	var config masker.Config
+	switch {
+	case configPath != "":
+		loaded, err := masker.LoadConfig(configPath)
+		if err != nil {
+			return nil, err
+		}
+		config = loaded
+	case len(bundles) == 0:
+		config = masker.DefaultConfig()
+		config.Patterns = append(config.Patterns, masker.URLPattern())
+		config.SensitiveKeys = masker.DefaultSensitiveKeys()
+		config.KeyValueDetection = true
+	}
+
+	opts = append([]masker.Option{masker.WithConfig(config), masker.WithBundle(bundles...)}, opts...)
+	return masker.NewWithOpts(opts...).(*masker.DefaultMasker), nil
+}
+
+func maskFile(dm *masker.DefaultMasker, format, path string, w, stderr io.Writer) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return maskStream(dm, format, path, f, w, stderr)
+}
+
+// maskInPlace writes the masked content to a temporary file next to path
+// and renames it over the original, so a failure never leaves a half
+// written file behind.
+func maskInPlace(dm *masker.DefaultMasker, format, path string, stderr io.Writer) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := maskFile(dm, format, path, tmp, stderr); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(info.Mode().Perm()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func maskStream(dm *masker.DefaultMasker, format, path string, r io.Reader, w, stderr io.Writer) error {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if format == "auto" {
+		format = detectFormat(path, data)
+	}
+
+	switch format {
+	case "json":
+		masked, err := dm.MaskJSON(data)
+		if err != nil {
+			// A .json file holding one record per line is masked as NDJSON;
+			// anything else fails rather than dropping what follows the
+			// first value.
+			if isNDJSON(bytes.TrimSpace(data)) {
+				return maskStream(dm, "ndjson", path, bytes.NewReader(data), w, stderr)
+			}
+			return err
+		}
+		_, err = w.Write(append(masked, '\n'))
+		return err
+	case "ndjson":
+		result, err := dm.MaskNDJSON(bytes.NewReader(data), w)
+		for _, malformed := range result.Malformed {
+			fmt.Fprintf(stderr, "go-sensitive: %s: line %d: masked as text: %v\n", displayName(path), malformed.Line, malformed.Err)
+		}
+		return err
+	case "csv":
+		return dm.MaskCSV(bytes.NewReader(data), w, masker.CSVOptions{})
+	case "tsv":
+		return dm.MaskCSV(bytes.NewReader(data), w, masker.CSVOptions{Comma: '\t', LazyQuotes: true})
+	case "xml":
+		return dm.MaskXML(bytes.NewReader(data), w)
+	default:
+		_, err = io.WriteString(w, dm.Mask(string(data)).(string))
+		return err
+	}
+}
+
+func displayName(path string) string {
+	if path == "" {
+		return "stdin"
+	}
+	return path
+}
+
+func isFormat(format string) bool {
+	switch format {
+	case "auto", "text", "json", "ndjson", "csv", "tsv", "xml":
+		return true
+	}
+	return false
+}
+
+func detectFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".csv":
+		return "csv"
+	case ".tsv":
+		return "tsv"
+	case ".xml":
+		return "xml"
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case len(trimmed) == 0:
+		return "text"
+	case trimmed[0] == '<':
+		return "xml"
+	case trimmed[0] != '{' && trimmed[0] != '[':
+		return "text"
+	case json.Valid(trimmed):
+		return "json"
+	}
+
+	if isNDJSON(trimmed) {
+		return "ndjson"
+	}
+	return "text"
+}
+
+func isNDJSON(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 && !json.Valid(line) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,471 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+)
+
+const (
+	// IgnoreFileName is read from every scanned directory root when present.
+	IgnoreFileName = ".go-sensitive-ignore"
+	// AllowDirective on a line suppresses the findings on that line. It can
+	// be followed by a comma separated list of pattern names.
+	AllowDirective = "go-sensitive:allow"
+
+	defaultMaxScanFileSize = 10 << 20
+	sarifSchema            = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+const scanUsage = `Usage: go-sensitive scan [flags] <paths...>
+
+Scans files and directories for sensitive data and reports the findings.
+Exits with 1 when a finding is at or above --fail-on, 2 on errors.
+
+Binary files and files larger than --max-size are skipped and listed on
+stderr; with --fail-on-skip a skipped file is an error.
+
+Paths matching a pattern of the ignore files are skipped. A line containing
+"go-sensitive:allow" is not reported; "go-sensitive:allow email,jwt" only
+allows the listed patterns.
+
+Flags:
+`
+
+type skippedFile struct {
+	Path   string
+	Reason string
+}
+
+type scanFinding struct {
+	Path      string          `json:"path"`
+	Line      int             `json:"line"`
+	Column    int             `json:"column"`
+	EndLine   int             `json:"end_line"`
+	EndColumn int             `json:"end_column"`
+	Pattern   string          `json:"pattern"`
+	Severity  masker.Severity `json:"severity"`
+}
+
+func runScan(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("go-sensitive scan", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, scanUsage)
+		flags.PrintDefaults()
+	}
+
+	var bundles, ignoreFiles stringList
+	format := flags.String("format", "text", "output format: text, json or sarif")
+	configPath := flags.String("config", "", "JSON or YAML rules file")
+	flags.Var(&bundles, "bundle", "pattern bundle to enable, repeatable or comma separated ("+strings.Join(masker.Bundles(), ", ")+")")
+	flags.Var(&ignoreFiles, "ignore-file", "additional ignore file, repeatable")
+	failOn := flags.String("fail-on", "low", "lowest severity that makes the scan fail: low, medium, high or critical")
+	maxSize := flags.Int64("max-size", defaultMaxScanFileSize, "largest file to scan in bytes, 0 for no limit")
+	failOnSkip := flags.Bool("fail-on-skip", false, "exit with 2 when a file is skipped")
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	threshold, err := masker.ParseSeverity(*failOn)
+	if err != nil || threshold == masker.SeverityUnknown {
+		fmt.Fprintf(stderr, "go-sensitive: invalid --fail-on %q\n", *failOn)
+		return 2
+	}
+	switch *format {
+	case "text", "json", "sarif":
+	default:
+		fmt.Fprintf(stderr, "go-sensitive: unknown scan format %q\n", *format)
+		return 2
+	}
+	if *maxSize < 0 {
+		fmt.Fprintf(stderr, "go-sensitive: invalid --max-size %d\n", *maxSize)
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	// The string length and time limits make masking fail closed, which
+	// would hide every finding of a large file.
+	dm, err := buildMasker(*configPath, bundles, masker.WithMaxStringLength(0), masker.WithTimeBudget(0))
+	if err != nil {
+		fmt.Fprintf(stderr, "go-sensitive: %v\n", err)
+		return 2
+	}
+
+	var ignore ignoreRules
+	for _, file := range ignoreFiles {
+		if err := ignore.load(file, ""); err != nil {
+			fmt.Fprintf(stderr, "go-sensitive: %v\n", err)
+			return 2
+		}
+	}
+
+	status := 0
+	var findings []scanFinding
+	for _, root := range flags.Args() {
+		found, skipped, err := scanPath(dm, root, ignore, *maxSize)
+		findings = append(findings, found...)
+		for _, skip := range skipped {
+			fmt.Fprintf(stderr, "go-sensitive: skipped %s: %s\n", skip.Path, skip.Reason)
+			if *failOnSkip {
+				status = 2
+			}
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "go-sensitive: %v\n", err)
+			status = 2
+		}
+	}
+
+	switch *format {
+	case "json":
+		err = writeJSONFindings(stdout, findings)
+	case "sarif":
+		err = writeSARIF(stdout, findings)
+	default:
+		err = writeTextFindings(stdout, findings)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "go-sensitive: %v\n", err)
+		return 2
+	}
+
+	if status == 0 {
+		for _, f := range findings {
+			if f.Severity >= threshold {
+				return 1
+			}
+		}
+	}
+	return status
+}
+
+func scanPath(dm *masker.DefaultMasker, root string, ignore ignoreRules, maxSize int64) ([]scanFinding, []skippedFile, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.IsDir() {
+		findings, skip, err := scanFile(dm, root, maxSize)
+		if skip != nil {
+			return findings, []skippedFile{*skip}, err
+		}
+		return findings, nil, err
+	}
+
+	if err := ignore.load(filepath.Join(root, IgnoreFileName), root); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+
+	var findings []scanFinding
+	var skipped []skippedFile
+	var errs []error
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			errs = append(errs, err)
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if ignore.match(p, d.IsDir()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() || d.Name() == IgnoreFileName {
+			return nil
+		}
+
+		found, skip, err := scanFile(dm, p, maxSize)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if skip != nil {
+			skipped = append(skipped, *skip)
+		}
+		findings = append(findings, found...)
+		return nil
+	})
+	if err != nil {
+		errs = append(errs, err)
+	}
+	return findings, skipped, errors.Join(errs...)
+}
+
+// scanFile returns the findings of the file at p, or why it was not scanned.
+func scanFile(dm *masker.DefaultMasker, p string, maxSize int64) ([]scanFinding, *skippedFile, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	if maxSize > 0 && info.Size() > maxSize {
+		reason := fmt.Sprintf("larger than %d bytes (raise --max-size to scan it)", maxSize)
+		return nil, &skippedFile{Path: filepath.ToSlash(p), Reason: reason}, nil
+	}
+
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isBinary(data) {
+		return nil, &skippedFile{Path: filepath.ToSlash(p), Reason: "binary file"}, nil
+	}
+
+	content := string(data)
+	_, report := dm.MaskWithReport(content)
+	lines := lineStarts(content)
+
+	var findings []scanFinding
+	for _, f := range report.Masked() {
+		if f.Pattern == masker.LimitPattern {
+			return nil, &skippedFile{Path: filepath.ToSlash(p), Reason: f.Reason}, nil
+		}
+		if f.End <= f.Start {
+			continue
+		}
+		line, column := position(content, lines, f.Start)
+		endLine, endColumn := position(content, lines, f.End)
+		if allowed(content, lines, line, f.Pattern) {
+			continue
+		}
+		findings = append(findings, scanFinding{
+			Path:      filepath.ToSlash(p),
+			Line:      line,
+			Column:    column,
+			EndLine:   endLine,
+			EndColumn: endColumn,
+			Pattern:   f.Pattern,
+			Severity:  f.Severity,
+		})
+	}
+	return findings, nil, nil
+}
+
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+func lineStarts(content string) []int {
+	starts := []int{0}
+	for i := 0; i < len(content); i++ {
+		if content[i] == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	return starts
+}
+
+// position converts a byte offset to a 1-based line and a 1-based column
+// counted in characters.
+func position(content string, lines []int, offset int) (int, int) {
+	line := sort.Search(len(lines), func(i int) bool { return lines[i] > offset }) - 1
+	return line + 1, utf8.RuneCountInString(content[lines[line]:offset]) + 1
+}
+
+func allowed(content string, lines []int, line int, pattern string) bool {
+	text := content[lines[line-1]:]
+	if end := strings.IndexByte(text, '\n'); end >= 0 {
+		text = text[:end]
+	}
+
+	i := strings.Index(text, AllowDirective)
+	if i < 0 {
+		return false
+	}
+	fields := strings.Fields(text[i+len(AllowDirective):])
+	if len(fields) == 0 || strings.TrimLeft(fields[0], "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-,") != "" {
+		return true
+	}
+	for _, name := range strings.Split(fields[0], ",") {
+		if name == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+type ignoreRule struct {
+	base    string
+	pattern string
+	dirOnly bool
+	negate  bool
+}
+
+// ignoreRules implements the common subset of .gitignore: one glob per line,
+// '#' comments, '!' negation, a trailing '/' for directories and a leading
+// '/' or inner '/' to anchor the pattern at the ignore file's directory.
+type ignoreRules []ignoreRule
+
+func (r *ignoreRules) load(file, base string) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rule := ignoreRule{base: base}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.Contains(line, "/") {
+			line = "/" + strings.TrimPrefix(line, "/")
+		}
+		if _, err := path.Match(strings.TrimPrefix(line, "/"), ""); err != nil {
+			return fmt.Errorf("%s: invalid pattern %q: %w", file, line, err)
+		}
+		rule.pattern = line
+		*r = append(*r, rule)
+	}
+	return scanner.Err()
+}
+
+func (r ignoreRules) match(p string, isDir bool) bool {
+	ignored := false
+	for _, rule := range r {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		rel := filepath.ToSlash(p)
+		if rule.base != "" {
+			r, err := filepath.Rel(rule.base, p)
+			if err != nil || r == "." || strings.HasPrefix(r, "..") {
+				continue
+			}
+			rel = filepath.ToSlash(r)
+		}
+
+		var matched bool
+		if strings.HasPrefix(rule.pattern, "/") {
+			matched, _ = path.Match(rule.pattern[1:], rel)
+		} else {
+			matched, _ = path.Match(rule.pattern, path.Base(rel))
+		}
+		if matched {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+func writeTextFindings(w io.Writer, findings []scanFinding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintf(w, "%s:%d:%d: %s %s\n", f.Path, f.Line, f.Column, f.Severity, f.Pattern); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSONFindings(w io.Writer, findings []scanFinding) error {
+	if findings == nil {
+		findings = []scanFinding{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(findings)
+}
+
+func writeSARIF(w io.Writer, findings []scanFinding) error {
+	type object = map[string]interface{}
+
+	severities := make(map[string]masker.Severity)
+	var ruleIDs []string
+	for _, f := range findings {
+		if _, ok := severities[f.Pattern]; !ok {
+			ruleIDs = append(ruleIDs, f.Pattern)
+		}
+		if f.Severity > severities[f.Pattern] {
+			severities[f.Pattern] = f.Severity
+		}
+	}
+	sort.Strings(ruleIDs)
+
+	rules := make([]object, 0, len(ruleIDs))
+	for _, id := range ruleIDs {
+		rules = append(rules, object{
+			"id":                   id,
+			"shortDescription":     object{"text": "Sensitive data detected by the " + id + " pattern"},
+			"defaultConfiguration": object{"level": sarifLevel(severities[id])},
+		})
+	}
+
+	results := make([]object, 0, len(findings))
+	for _, f := range findings {
+		results = append(results, object{
+			"ruleId":  f.Pattern,
+			"level":   sarifLevel(f.Severity),
+			"message": object{"text": fmt.Sprintf("%s sensitive data (%s)", f.Severity, f.Pattern)},
+			"locations": []object{{
+				"physicalLocation": object{
+					"artifactLocation": object{"uri": f.Path},
+					"region": object{
+						"startLine":   f.Line,
+						"startColumn": f.Column,
+						"endLine":     f.EndLine,
+						"endColumn":   f.EndColumn,
+					},
+				},
+			}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(object{
+		"$schema": sarifSchema,
+		"version": "2.1.0",
+		"runs": []object{{
+			"tool":       object{"driver": object{"name": "go-sensitive", "rules": rules}},
+			"columnKind": "unicodeCodePoints",
+			"results":    results,
+		}},
+	})
+}
+
+func sarifLevel(severity masker.Severity) string {
+	switch {
+	case severity >= masker.SeverityHigh:
+		return "error"
+	case severity == masker.SeverityMedium:
+		return "warning"
+	default:
+		return "note"
+	}
+}
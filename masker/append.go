@@ -0,0 +1,20 @@
+package masker
+
+func AppendMask(dst, src []byte) []byte {
+	return defaultMasker().AppendMask(dst, src)
+}
+
+// AppendMask appends the masked form of src to dst and returns the extended
+// buffer, like the strconv Append functions. When src cannot contain a match
+// it is copied as is without allocating beyond the growth of dst.
+func (dm *DefaultMasker) AppendMask(dst, src []byte) []byte {
+	if !dm.compiled {
+		return append(dst, src...)
+	}
+	if dm.maxStringLength <= 0 || len(src) <= dm.maxStringLength {
+		if !dm.prefilter.mayMatch(src) || !dm.cache.Match(src) {
+			return append(dst, src...)
+		}
+	}
+	return append(dst, dm.maskString(dm.newWalk(nil), string(src))...)
+}
@@ -0,0 +1,98 @@
+package masker
+
+import (
+	"fmt"
+	"regexp/syntax"
+	"time"
+)
+
+const (
+	maxLintRepeat         = 100
+	maxLintProgramSize    = 5000
+	reasonMaxStringLength = "max string length exceeded"
+	reasonTimeBudget      = "time budget exceeded"
+)
+
+// LintPattern returns warnings about constructs that make a regex expensive
+// to run on large inputs. Go's regexp engine runs in linear time, but nested
+// and large counted repetitions blow up the compiled program and leading
+// wildcards make every match scan the rest of the input.
+func LintPattern(regex string) []string {
+	re, err := syntax.Parse(regex, syntax.Perl)
+	if err != nil {
+		return []string{fmt.Sprintf("invalid regex: %v", err)}
+	}
+
+	var warnings []string
+	if startsWithWildcard(re) {
+		warnings = append(warnings, "leading wildcard scans the remainder of the input for every candidate")
+	}
+	lintRepeats(re, false, &warnings)
+
+	if prog, err := syntax.Compile(re.Simplify()); err == nil && len(prog.Inst) > maxLintProgramSize {
+		warnings = append(warnings, fmt.Sprintf("compiled program has %d instructions", len(prog.Inst)))
+	}
+	return warnings
+}
+
+func lintRepeats(re *syntax.Regexp, insideUnbounded bool, warnings *[]string) {
+	unbounded := false
+	switch re.Op {
+	case syntax.OpStar, syntax.OpPlus:
+		unbounded = true
+	case syntax.OpRepeat:
+		unbounded = re.Max == -1
+		if re.Min > maxLintRepeat || re.Max > maxLintRepeat {
+			*warnings = append(*warnings, fmt.Sprintf("large counted repetition {%d,%d}", re.Min, re.Max))
+		}
+	}
+	if unbounded && insideUnbounded {
+		*warnings = append(*warnings, "nested unbounded quantifier "+re.String())
+		return
+	}
+	for _, sub := range re.Sub {
+		lintRepeats(sub, insideUnbounded || unbounded, warnings)
+	}
+}
+
+func startsWithWildcard(re *syntax.Regexp) bool {
+	for {
+		switch re.Op {
+		case syntax.OpConcat, syntax.OpCapture:
+			if len(re.Sub) == 0 {
+				return false
+			}
+			re = re.Sub[0]
+		case syntax.OpStar, syntax.OpPlus:
+			op := re.Sub[0].Op
+			return op == syntax.OpAnyChar || op == syntax.OpAnyCharNotNL
+		default:
+			return false
+		}
+	}
+}
+
+// lintPatterns reports LintPattern warnings to handler. Without a handler
+// patterns are not linted; call LintPattern to check them explicitly.
+func (dm *DefaultMasker) lintPatterns(handler func(pattern, message string)) {
+	if handler == nil {
+		return
+	}
+	for _, pattern := range dm.patterns {
+		if pattern.Regex == "" {
+			continue
+		}
+		for _, warning := range LintPattern(pattern.Regex) {
+			handler(pattern.Name, warning)
+		}
+	}
+}
+
+func (st walkState) budgetExceeded() bool {
+	return st.shared != nil && !st.shared.deadline.IsZero() && time.Now().After(st.shared.deadline)
+}
+
+func (dm *DefaultMasker) failClosed(st walkState, reason string) string {
+	st.recordLimit(reason)
+	return RedactedValue
+}
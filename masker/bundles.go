@@ -0,0 +1,222 @@
+package masker
+
+import (
+	"sort"
+	"strings"
+)
+
+var bundles = map[string]func() []Pattern{
+	"default": DefaultPatterns,
+	"pci":     pciPatterns,
+	"br":      brazilPatterns,
+	"contact": contactPatterns,
+	"secrets": secretPatterns,
+	"banking": bankingPatterns,
+	"urls":    urlPatterns,
+}
+
+func Bundles() []string {
+	names := make([]string, 0, len(bundles))
+	for name := range bundles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func BundlePatterns(name string) ([]Pattern, bool) {
+	bundle, ok := bundles[strings.ToLower(name)]
+	if !ok {
+		return nil, false
+	}
+	return bundle(), true
+}
+
+func WithBundle(names ...string) Option {
+	return func(c *Config) {
+		for _, name := range names {
+			if patterns, ok := BundlePatterns(name); ok {
+				c.Patterns = append(c.Patterns, patterns...)
+			}
+		}
+	}
+}
+
+func pciPatterns() []Pattern {
+	return []Pattern{
+		{
+			Name:     "credit_card",
+			Regex:    `\b(?:\d[ -]?){12,18}\d\b`,
+			Severity: SeverityHigh,
+			Priority: 20,
+			Validate: func(card string) bool {
+				clean := CleanNumber(card)
+				return len(clean) >= 13 && len(clean) <= 19 && IsLuhnValid(clean)
+			},
+			MaskFunc: func(card string) string {
+				clean := CleanNumber(card)
+				if len(clean) < 13 {
+					return card
+				}
+				return clean[:4] + strings.Repeat("*", len(clean)-8) + clean[len(clean)-4:]
+			},
+		},
+		{
+			Name:           "cvv",
+			Regex:          `\b\d{3,4}\b`,
+			Severity:       SeverityHigh,
+			Keywords:       []string{"cvv", "cvc", "cvv2", "security code", "código de segurança"},
+			KeywordWindow:  16,
+			RequireKeyword: true,
+			MaskFunc:       Replace("***"),
+		},
+	}
+}
+
+func brazilPatterns() []Pattern {
+	return []Pattern{
+		{
+			Name:     "cnpj",
+			Regex:    `\b\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2}\b`,
+			Severity: SeverityMedium,
+			Priority: 15,
+			Validate: IsCNPJValid,
+			MaskFunc: func(cnpj string) string {
+				clean := CleanNumber(cnpj)
+				if len(clean) != 14 {
+					return cnpj
+				}
+				return clean[:2] + "***.***/****-" + clean[12:]
+			},
+		},
+		{
+			Name:     "cpf",
+			Regex:    `\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`,
+			Severity: SeverityMedium,
+			Priority: 15,
+			Validate: IsCPFValid,
+			MaskFunc: func(cpf string) string {
+				clean := CleanNumber(cpf)
+				if len(clean) != 11 {
+					return cpf
+				}
+				return clean[:3] + "***.***-" + clean[9:]
+			},
+		},
+		{
+			Name:     "phone_br",
+			Regex:    `(?:\+55\s?)?\(?\b\d{2}\)?\s?9?\d{4}-?\d{4}\b`,
+			Severity: SeverityLow,
+			Priority: 5,
+			MaskFunc: func(phone string) string {
+				digits := CleanNumber(strings.TrimPrefix(phone, "+"))
+				if len(digits) < 8 {
+					return phone
+				}
+				return strings.Repeat("*", len(digits)-4) + digits[len(digits)-4:]
+			},
+		},
+	}
+}
+
+func contactPatterns() []Pattern {
+	return []Pattern{
+		{
+			Name:     "email",
+			Regex:    `\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`,
+			Severity: SeverityLow,
+			Priority: 10,
+			MaskFunc: func(email string) string {
+				user, domain, _ := strings.Cut(email, "@")
+				if len(user) > 2 {
+					return user[:2] + "***@" + domain
+				}
+				return "***@" + domain
+			},
+		},
+		{
+			Name:     "phone",
+			Regex:    `\+\d{1,3}[\s-]?\(?\d{1,4}\)?(?:[\s-]?\d{2,5}){2,3}\b`,
+			Severity: SeverityLow,
+			Priority: 5,
+			MaskFunc: PartialMask(3, 2),
+		},
+	}
+}
+
+func urlPatterns() []Pattern {
+	return []Pattern{URLPattern()}
+}
+
+func secretPatterns() []Pattern {
+	return []Pattern{
+		{
+			Name:     "private_key",
+			Regex:    `-----BEGIN (?:RSA |EC |DSA |OPENSSH |ENCRYPTED )?PRIVATE KEY-----[\s\S]*?-----END (?:RSA |EC |DSA |OPENSSH |ENCRYPTED )?PRIVATE KEY-----`,
+			Severity: SeverityCritical,
+			Priority: 100,
+			MaskFunc: Redact,
+		},
+		{
+			Name:     "aws_access_key",
+			Regex:    `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`,
+			Severity: SeverityCritical,
+			Priority: 90,
+			MaskFunc: PartialMask(4, 0),
+		},
+		{
+			Name:     "github_token",
+			Regex:    `\bgh[pousr]_[A-Za-z0-9]{36,}\b`,
+			Severity: SeverityCritical,
+			Priority: 90,
+			MaskFunc: PartialMask(4, 0),
+		},
+		{
+			Name:     "stripe_key",
+			Regex:    `\b(?:sk|rk|pk)_(?:live|test)_[A-Za-z0-9]{16,}\b`,
+			Severity: SeverityCritical,
+			Priority: 90,
+			MaskFunc: PartialMask(8, 0),
+		},
+		{
+			Name:     "slack_token",
+			Regex:    `\bxox[abposr]-[A-Za-z0-9-]{10,}\b`,
+			Severity: SeverityCritical,
+			Priority: 90,
+			MaskFunc: PartialMask(5, 0),
+		},
+		{
+			Name:     "google_api_key",
+			Regex:    `\bAIza[0-9A-Za-z_\-]{35}\b`,
+			Severity: SeverityHigh,
+			Priority: 90,
+			MaskFunc: PartialMask(4, 0),
+		},
+		{
+			Name:     "jwt",
+			Regex:    `\beyJ[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]{5,}\.[A-Za-z0-9_-]+`,
+			Severity: SeverityHigh,
+			Priority: 80,
+			MaskFunc: Redact,
+		},
+	}
+}
+
+func bankingPatterns() []Pattern {
+	return []Pattern{
+		{
+			Name:     "iban",
+			Regex:    `\b[A-Z]{2}\d{2}(?: ?[A-Z0-9]{4}){2,7}(?: ?[A-Z0-9]{1,3})?\b`,
+			Severity: SeverityMedium,
+			Priority: 20,
+			Validate: IsIBANValid,
+			MaskFunc: func(iban string) string {
+				clean := strings.ReplaceAll(iban, " ", "")
+				if len(clean) < 15 {
+					return iban
+				}
+				return clean[:4] + strings.Repeat("*", len(clean)-8) + clean[len(clean)-4:]
+			},
+		},
+	}
+}
@@ -0,0 +1,346 @@
+package masker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+type FileConfig struct {
+	Bundles           []string      `json:"bundles" yaml:"bundles"`
+	Patterns          []PatternSpec `json:"patterns" yaml:"patterns"`
+	SensitiveKeys     []string      `json:"sensitive_keys" yaml:"sensitive_keys"`
+	KeyValueDetection bool          `json:"key_value_detection" yaml:"key_value_detection"`
+	KeyMask           *StrategySpec `json:"key_mask" yaml:"key_mask"`
+	OverlapMode       string        `json:"overlap_mode" yaml:"overlap_mode"`
+	MinConfidence     float64       `json:"min_confidence" yaml:"min_confidence"`
+	Entropy           *EntropySpec  `json:"entropy" yaml:"entropy"`
+	URLCredentials    *URLSpec      `json:"url_credentials" yaml:"url_credentials"`
+	Limits            LimitsSpec    `json:"limits" yaml:"limits"`
+	Strict            bool          `json:"strict" yaml:"strict"`
+}
+
+type PatternSpec struct {
+	Name           string         `json:"name" yaml:"name"`
+	Regex          string         `json:"regex" yaml:"regex"`
+	Priority       int            `json:"priority" yaml:"priority"`
+	Severity       string         `json:"severity" yaml:"severity"`
+	Validator      *ValidatorSpec `json:"validator" yaml:"validator"`
+	Strategy       *StrategySpec  `json:"strategy" yaml:"strategy"`
+	Keywords       []string       `json:"keywords" yaml:"keywords"`
+	KeywordWindow  int            `json:"keyword_window" yaml:"keyword_window"`
+	RequireKeyword bool           `json:"require_keyword" yaml:"require_keyword"`
+	Confidence     float64        `json:"confidence" yaml:"confidence"`
+}
+
+type ValidatorSpec struct {
+	Type    string   `json:"type" yaml:"type"`
+	Min     string   `json:"min" yaml:"min"`
+	Max     string   `json:"max" yaml:"max"`
+	Layouts []string `json:"layouts" yaml:"layouts"`
+}
+
+type StrategySpec struct {
+	Type      string `json:"type" yaml:"type"`
+	KeepFirst int    `json:"keep_first" yaml:"keep_first"`
+	KeepLast  int    `json:"keep_last" yaml:"keep_last"`
+	Salt      string `json:"salt" yaml:"salt"`
+	Value     string `json:"value" yaml:"value"`
+}
+
+type EntropySpec struct {
+	MinLength       int      `json:"min_length" yaml:"min_length"`
+	Base64Threshold float64  `json:"base64_threshold" yaml:"base64_threshold"`
+	HexThreshold    float64  `json:"hex_threshold" yaml:"hex_threshold"`
+	Allowlist       []string `json:"allowlist" yaml:"allowlist"`
+	// ReplaceDefaults makes Allowlist replace DefaultEntropyAllowlist instead
+	// of extending it.
+	ReplaceDefaults bool `json:"replace_defaults" yaml:"replace_defaults"`
+}
+
+type URLSpec struct {
+	Params []string `json:"params" yaml:"params"`
+}
+
+type LimitsSpec struct {
+	MaxDepth        int    `json:"max_depth" yaml:"max_depth"`
+	MaxElements     int    `json:"max_elements" yaml:"max_elements"`
+	MaxStringLength int    `json:"max_string_length" yaml:"max_string_length"`
+	TimeBudget      string `json:"time_budget" yaml:"time_budget"`
+	Policy          string `json:"policy" yaml:"policy"`
+}
+
+// ConfigError points at the entry of a configuration file that failed
+// validation, e.g. "patterns[2].regex".
+type ConfigError struct {
+	Field string
+	Err   error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	var config Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		config, err = ConfigFromJSON(data)
+	case ".yaml", ".yml":
+		config, err = ConfigFromYAML(data)
+	default:
+		return Config{}, fmt.Errorf("%s: unsupported config format %q", path, filepath.Ext(path))
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+func ConfigFromJSON(data []byte) (Config, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var file FileConfig
+	if err := decoder.Decode(&file); err != nil {
+		return Config{}, err
+	}
+	return file.Build()
+}
+
+func ConfigFromYAML(data []byte) (Config, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var file FileConfig
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, err
+	}
+	return file.Build()
+}
+
+func WithConfig(config Config) Option {
+	return func(c *Config) {
+		*c = config
+	}
+}
+
+// Build validates the file configuration and turns it into a Config. All
+// invalid entries are reported at once, joined with errors.Join.
+func (f FileConfig) Build() (Config, error) {
+	var config Config
+	var errs []error
+	fail := func(field string, format string, args ...interface{}) {
+		errs = append(errs, &ConfigError{Field: field, Err: fmt.Errorf(format, args...)})
+	}
+
+	for i, name := range f.Bundles {
+		patterns, ok := BundlePatterns(name)
+		if !ok {
+			fail(fmt.Sprintf("bundles[%d]", i), "unknown bundle %q (available: %s)", name, strings.Join(Bundles(), ", "))
+			continue
+		}
+		config.Patterns = append(config.Patterns, patterns...)
+	}
+
+	names := make(map[string]int)
+	for i, spec := range f.Patterns {
+		field := fmt.Sprintf("patterns[%d]", i)
+		if spec.Name == "" {
+			fail(field+".name", "is required")
+		} else if previous, ok := names[spec.Name]; ok {
+			fail(field+".name", "duplicates patterns[%d] %q", previous, spec.Name)
+		} else {
+			names[spec.Name] = i
+		}
+
+		if spec.Regex == "" {
+			fail(field+".regex", "is required")
+		} else if _, err := regexp.Compile(spec.Regex); err != nil {
+			fail(field+".regex", "%v", err)
+		}
+
+		pattern := Pattern{
+			Name:           spec.Name,
+			Regex:          spec.Regex,
+			Priority:       spec.Priority,
+			Keywords:       spec.Keywords,
+			KeywordWindow:  spec.KeywordWindow,
+			RequireKeyword: spec.RequireKeyword,
+			Confidence:     spec.Confidence,
+			MaskFunc:       Redact,
+		}
+		if spec.Validator != nil {
+			validate, err := spec.Validator.build()
+			if err != nil {
+				fail(field+".validator", "%v", err)
+			}
+			pattern.Validate = validate
+		}
+		if spec.Strategy != nil {
+			maskFunc, err := spec.Strategy.build()
+			if err != nil {
+				fail(field+".strategy", "%v", err)
+			}
+			pattern.MaskFunc = maskFunc
+		}
+		if spec.Severity != "" {
+			severity, err := ParseSeverity(spec.Severity)
+			if err != nil {
+				fail(field+".severity", "%v", err)
+			}
+			pattern.Severity = severity
+		}
+		if spec.Confidence < 0 || spec.Confidence > 1 {
+			fail(field+".confidence", "must be between 0 and 1")
+		}
+		config.Patterns = append(config.Patterns, pattern)
+	}
+
+	config.SensitiveKeys = f.SensitiveKeys
+	config.KeyValueDetection = f.KeyValueDetection
+	if f.KeyMask != nil {
+		maskFunc, err := f.KeyMask.build()
+		if err != nil {
+			fail("key_mask", "%v", err)
+		}
+		config.KeyMaskFunc = maskFunc
+	}
+
+	switch strings.ToLower(f.OverlapMode) {
+	case "", "first", "first_match":
+		config.OverlapMode = OverlapFirstMatch
+	case "leftmost_longest":
+		config.OverlapMode = OverlapLeftmostLongest
+	case "priority":
+		config.OverlapMode = OverlapPriority
+	default:
+		fail("overlap_mode", "unknown mode %q (expected first_match, leftmost_longest or priority)", f.OverlapMode)
+	}
+
+	if f.MinConfidence < 0 || f.MinConfidence > 1 {
+		fail("min_confidence", "must be between 0 and 1")
+	}
+	config.MinConfidence = f.MinConfidence
+
+	if f.Entropy != nil {
+		valid := true
+		for i, expr := range f.Entropy.Allowlist {
+			if _, err := regexp.Compile(expr); err != nil {
+				fail(fmt.Sprintf("entropy.allowlist[%d]", i), "%v", err)
+				valid = false
+			}
+		}
+		if valid {
+			config.Patterns = append(config.Patterns, EntropyPattern(EntropyConfig{
+				MinLength:       f.Entropy.MinLength,
+				Base64Threshold: f.Entropy.Base64Threshold,
+				HexThreshold:    f.Entropy.HexThreshold,
+				Allowlist:       f.Entropy.Allowlist,
+				ReplaceDefaults: f.Entropy.ReplaceDefaults,
+			}))
+		}
+	}
+	if f.URLCredentials != nil {
+		config.Patterns = append(config.Patterns, URLPattern(f.URLCredentials.Params...))
+	}
+
+	limits := f.Limits
+	if limits.MaxDepth < 0 {
+		fail("limits.max_depth", "must not be negative")
+	}
+	if limits.MaxElements < 0 {
+		fail("limits.max_elements", "must not be negative")
+	}
+	if limits.MaxStringLength < 0 {
+		fail("limits.max_string_length", "must not be negative")
+	}
+	config.MaxDepth = limits.MaxDepth
+	config.MaxElements = limits.MaxElements
+	config.MaxStringLength = limits.MaxStringLength
+	if limits.TimeBudget != "" {
+		budget, err := time.ParseDuration(limits.TimeBudget)
+		if err != nil || budget < 0 {
+			fail("limits.time_budget", "invalid duration %q", limits.TimeBudget)
+		}
+		config.TimeBudget = budget
+	}
+	switch strings.ToLower(limits.Policy) {
+	case "", "truncate":
+		config.LimitPolicy = LimitTruncate
+	case "redact":
+		config.LimitPolicy = LimitRedact
+	default:
+		fail("limits.policy", "unknown policy %q (expected truncate or redact)", limits.Policy)
+	}
+
+	config.StrictMode = f.Strict
+
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+	return config, nil
+}
+
+func (s ValidatorSpec) build() (func(string) bool, error) {
+	switch strings.ToLower(s.Type) {
+	case "luhn":
+		return IsLuhnValid, nil
+	case "cpf":
+		return IsCPFValid, nil
+	case "cnpj":
+		return IsCNPJValid, nil
+	case "iban":
+		return IsIBANValid, nil
+	case "date_range":
+		min, err := time.Parse("2006-01-02", s.Min)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min date %q", s.Min)
+		}
+		max, err := time.Parse("2006-01-02", s.Max)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max date %q", s.Max)
+		}
+		return DateInRange(min, max, s.Layouts...), nil
+	default:
+		return nil, fmt.Errorf("unknown validator %q (expected luhn, cpf, cnpj, iban or date_range)", s.Type)
+	}
+}
+
+func (s StrategySpec) build() (func(string) string, error) {
+	switch strings.ToLower(s.Type) {
+	case "redact":
+		return Redact, nil
+	case "partial":
+		if s.KeepFirst < 0 || s.KeepLast < 0 {
+			return nil, errors.New("keep_first and keep_last must not be negative")
+		}
+		return PartialMask(s.KeepFirst, s.KeepLast), nil
+	case "hash":
+		return HashSHA256(s.Salt), nil
+	case "replace":
+		return Replace(s.Value), nil
+	case "keep":
+		return Keep, nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q (expected redact, partial, hash, replace or keep)", s.Type)
+	}
+}
@@ -0,0 +1,90 @@
+package masker
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	DefaultKeywordWindow = 32
+	defaultContextBase   = 0.5
+	defaultKeywordBoost  = 0.5
+)
+
+func (dm *DefaultMasker) acceptMatch(st walkState, s string, m *match) bool {
+	if m.pattern.Validate != nil && !m.pattern.Validate(s[m.start:m.end]) {
+		st.record(*m, true, "validation failed")
+		return false
+	}
+
+	p := m.pattern
+	m.confidence = p.Confidence
+	if len(p.Keywords) == 0 {
+		if m.confidence == 0 {
+			m.confidence = 1
+		}
+	} else {
+		if m.confidence == 0 {
+			m.confidence = defaultContextBase
+		}
+		if hasContextKeyword(st.key, s, m) {
+			boost := p.KeywordBoost
+			if boost == 0 {
+				boost = defaultKeywordBoost
+			}
+			m.confidence = min(1, m.confidence+boost)
+		} else if p.RequireKeyword {
+			st.record(*m, true, "missing context keyword")
+			return false
+		}
+	}
+
+	if m.confidence < dm.minConfidence {
+		st.record(*m, true, "below confidence threshold")
+		return false
+	}
+	return true
+}
+
+func hasContextKeyword(key, s string, m *match) bool {
+	window := m.pattern.KeywordWindow
+	if window <= 0 {
+		window = DefaultKeywordWindow
+	}
+
+	key = strings.ToLower(key)
+	for _, keyword := range m.pattern.Keywords {
+		keyword = strings.ToLower(keyword)
+		if key != "" && strings.Contains(key, keyword) {
+			return true
+		}
+
+		reach := window + len(keyword)
+		before := strings.ToLower(s[runeFloor(s, m.start-reach):m.start])
+		after := strings.ToLower(s[m.end:runeCeil(s, m.end+reach)])
+		if strings.Contains(before, keyword) || strings.Contains(after, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func runeFloor(s string, i int) int {
+	if i <= 0 {
+		return 0
+	}
+	for i > 0 && !utf8.RuneStart(s[i]) {
+		i--
+	}
+	return i
+}
+
+func runeCeil(s string, i int) int {
+	if i >= len(s) {
+		return len(s)
+	}
+	for i < len(s) && !utf8.RuneStart(s[i]) {
+		i++
+	}
+	return i
+}
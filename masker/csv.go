@@ -0,0 +1,83 @@
+package masker
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+)
+
+type CSVOptions struct {
+	Comma      rune
+	Comment    rune
+	LazyQuotes bool
+	NoHeader   bool
+	// Columns maps header names to the function applied to every cell of
+	// that column. Names are matched like sensitive keys, ignoring case and
+	// separators. Cells of other columns go through the patterns.
+	Columns map[string]func(string) string
+}
+
+func MaskCSV(r io.Reader, w io.Writer, csvOpts CSVOptions, opts ...Option) error {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskCSV(r, w, csvOpts)
+}
+
+func (dm *DefaultMasker) MaskCSV(r io.Reader, w io.Writer, opts CSVOptions) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	reader.LazyQuotes = opts.LazyQuotes
+	reader.Comment = opts.Comment
+	writer := csv.NewWriter(w)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+		writer.Comma = opts.Comma
+	}
+
+	columns := make(map[string]func(string) string, len(opts.Columns))
+	for name, rule := range opts.Columns {
+		columns[normalizeKey(name)] = rule
+	}
+
+	st := dm.newWalk(nil)
+	var rules []func(string) string
+	header := !opts.NoHeader
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if header {
+			header = false
+			rules = make([]func(string) string, len(record))
+			for i, name := range record {
+				if rule, ok := columns[normalizeKey(name)]; ok {
+					rules[i] = rule
+				} else if IsSensitiveKey(name, dm.keyRules()) {
+					rules[i] = dm.keyMaskFunc
+				}
+			}
+		} else {
+			for i, cell := range record {
+				if cell == "" {
+					continue
+				}
+				if i < len(rules) && rules[i] != nil {
+					record[i] = rules[i](cell)
+				} else {
+					record[i] = dm.maskString(st, cell)
+				}
+			}
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
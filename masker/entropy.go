@@ -0,0 +1,187 @@
+package masker
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	DefaultEntropyMinLength       = 20
+	DefaultEntropyBase64Threshold = 4.3
+	DefaultEntropyHexThreshold    = 3.0
+	DefaultEntropyPriority        = -100
+)
+
+type EntropyConfig struct {
+	MinLength       int
+	Base64Threshold float64
+	HexThreshold    float64
+	// Allowlist extends DefaultEntropyAllowlist, or replaces it when
+	// ReplaceDefaults is set.
+	Allowlist       []string
+	ReplaceDefaults bool
+	Priority        int
+	MaskFunc        func(string) string
+}
+
+func DefaultEntropyAllowlist() []string {
+	return []string{
+		`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`,
+		`^[0-9a-fA-F]{32}$`,
+		`^[0-9a-fA-F]{40}$`,
+		`^[0-9a-fA-F]{64}$`,
+		`^[0-9a-fA-F]{128}$`,
+	}
+}
+
+func ShannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	var counts [256]int
+	for i := 0; i < len(s); i++ {
+		counts[s[i]]++
+	}
+
+	entropy := 0.0
+	length := float64(len(s))
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+func EntropyPattern(cfg EntropyConfig) Pattern {
+	if cfg.MinLength <= 0 {
+		cfg.MinLength = DefaultEntropyMinLength
+	}
+	if cfg.Base64Threshold <= 0 {
+		cfg.Base64Threshold = DefaultEntropyBase64Threshold
+	}
+	if cfg.HexThreshold <= 0 {
+		cfg.HexThreshold = DefaultEntropyHexThreshold
+	}
+	if !cfg.ReplaceDefaults {
+		cfg.Allowlist = append(DefaultEntropyAllowlist(), cfg.Allowlist...)
+	}
+	if cfg.Priority == 0 {
+		cfg.Priority = DefaultEntropyPriority
+	}
+	if cfg.MaskFunc == nil {
+		cfg.MaskFunc = Redact
+	}
+
+	allowlist := make([]*regexp.Regexp, len(cfg.Allowlist))
+	for i, expr := range cfg.Allowlist {
+		allowlist[i] = regexp.MustCompile(expr)
+	}
+
+	isSecret := func(token string) bool {
+		if len(token) < cfg.MinLength || IsAllDigits(token) {
+			return false
+		}
+		for _, re := range allowlist {
+			if re.MatchString(token) {
+				return false
+			}
+		}
+		if isHexToken(token) {
+			return ShannonEntropy(token) >= cfg.HexThreshold
+		}
+		return ShannonEntropy(token) >= cfg.Base64Threshold
+	}
+
+	return Pattern{
+		Name: "high_entropy",
+		// A token starts at the beginning of the input or after a character
+		// that cannot be part of it; that character is kept as is.
+		Regex:    fmt.Sprintf(`(?:^|[^A-Za-z0-9+/_\-])[A-Za-z0-9+/_\-]{%d,}={0,2}`, cfg.MinLength),
+		Priority: cfg.Priority,
+		Validate: func(s string) bool {
+			_, token := splitEntropyToken(s)
+			if !isPathToken(token) {
+				return isSecret(token)
+			}
+			for _, segment := range strings.Split(token, "/") {
+				if isSecret(segment) {
+					return true
+				}
+			}
+			return false
+		},
+		MaskFunc: func(s string) string {
+			prefix, token := splitEntropyToken(s)
+			if !isPathToken(token) {
+				return prefix + cfg.MaskFunc(token)
+			}
+			segments := strings.Split(token, "/")
+			for i, segment := range segments {
+				if isSecret(segment) {
+					segments[i] = cfg.MaskFunc(segment)
+				}
+			}
+			return prefix + strings.Join(segments, "/")
+		},
+	}
+}
+
+// splitEntropyToken separates the character preceding a high entropy token
+// from the token itself.
+func splitEntropyToken(s string) (string, string) {
+	if s == "" || isEntropyTokenByte(s[0]) {
+		return "", s
+	}
+	_, size := utf8.DecodeRuneInString(s)
+	return s[:size], s[size:]
+}
+
+func isEntropyTokenByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		c == '+' || c == '/' || c == '_' || c == '-'
+}
+
+// isPathToken reports whether token looks like a URL or file path rather
+// than base64, i.e. it starts with '/' or with a lowercase word followed by
+// '/'. Paths are checked one segment at a time.
+func isPathToken(token string) bool {
+	first, _, found := strings.Cut(token, "/")
+	if !found {
+		return false
+	}
+	if first == "" {
+		return true
+	}
+	if len(first) < 2 {
+		return false
+	}
+	for i := 0; i < len(first); i++ {
+		if c := first[i]; !(c >= 'a' && c <= 'z' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+func WithEntropyDetection(cfg EntropyConfig) Option {
+	return func(c *Config) {
+		c.Patterns = append(c.Patterns, EntropyPattern(cfg))
+	}
+}
+
+func isHexToken(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
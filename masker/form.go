@@ -0,0 +1,85 @@
+package masker
+
+import (
+	"net/url"
+	"strings"
+)
+
+func MaskForm(values url.Values, opts ...Option) url.Values {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskForm(values)
+}
+
+func MaskQuery(query string, opts ...Option) string {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskQuery(query)
+}
+
+// MaskForm masks every value of a parsed form. Values of parameters matching
+// the sensitive key rules (DefaultSensitiveKeys when none are configured) are
+// replaced with the key mask; all other values go through the patterns.
+func (dm *DefaultMasker) MaskForm(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+
+	st := dm.newWalk(nil)
+	result := make(url.Values, len(values))
+	for key, vals := range values {
+		masked := make([]string, len(vals))
+		for i, value := range vals {
+			masked[i] = dm.maskParam(st, key, value)
+		}
+		result[key] = masked
+	}
+	return result
+}
+
+// MaskQuery masks a raw query string the same way as MaskForm while keeping
+// the original parameter order and the encoding of untouched parameters.
+func (dm *DefaultMasker) MaskQuery(query string) string {
+	st := dm.newWalk(nil)
+
+	prefix := ""
+	if strings.HasPrefix(query, "?") {
+		prefix, query = "?", query[1:]
+	}
+	if query == "" {
+		return prefix
+	}
+
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		rawKey, rawValue, found := strings.Cut(pair, "=")
+		if !found || rawValue == "" {
+			continue
+		}
+		key, err := url.QueryUnescape(rawKey)
+		if err != nil {
+			key = rawKey
+		}
+		value, err := url.QueryUnescape(rawValue)
+		if err != nil {
+			value = rawValue
+		}
+		if masked := dm.maskParam(st, key, value); masked != value {
+			pairs[i] = rawKey + "=" + url.QueryEscape(masked)
+		}
+	}
+	return prefix + strings.Join(pairs, "&")
+}
+
+func (dm *DefaultMasker) maskParam(st walkState, key, value string) string {
+	if value == "" {
+		return value
+	}
+	if IsSensitiveKey(key, dm.keyRules()) {
+		return dm.keyMaskFunc(value)
+	}
+	return dm.maskString(st, value)
+}
+
+func (dm *DefaultMasker) keyRules() []string {
+	if len(dm.sensitiveKeys) > 0 {
+		return dm.sensitiveKeys
+	}
+	return DefaultSensitiveKeys()
+}
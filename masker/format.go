@@ -0,0 +1,186 @@
+package masker
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+var defaultMasker = sync.OnceValue(func() *DefaultMasker {
+	return newFromConfig(DefaultConfig())
+})
+
+func Sprintf(format string, args ...interface{}) string {
+	return defaultMasker().Sprintf(format, args...)
+}
+
+func Fprintf(w io.Writer, format string, args ...interface{}) (int, error) {
+	return defaultMasker().Fprintf(w, format, args...)
+}
+
+func Errorf(format string, args ...interface{}) error {
+	return defaultMasker().Errorf(format, args...)
+}
+
+func (dm *DefaultMasker) Sprintf(format string, args ...interface{}) string {
+	st := dm.newWalk(nil)
+	return dm.maskString(st, fmt.Sprintf(format, dm.maskArgs(st, args)...))
+}
+
+func (dm *DefaultMasker) Fprintf(w io.Writer, format string, args ...interface{}) (int, error) {
+	return io.WriteString(w, dm.Sprintf(format, args...))
+}
+
+func (dm *DefaultMasker) Errorf(format string, args ...interface{}) error {
+	st := dm.newWalk(nil)
+	return dm.maskError(st, fmt.Errorf(format, dm.maskArgs(st, args)...))
+}
+
+func (dm *DefaultMasker) maskArgs(st walkState, args []interface{}) []interface{} {
+	masked := make([]interface{}, len(args))
+	for i, arg := range args {
+		masked[i] = dm.maskArg(st, arg)
+	}
+	return masked
+}
+
+func (dm *DefaultMasker) maskArg(st walkState, arg interface{}) interface{} {
+	switch v := arg.(type) {
+	case nil:
+		return nil
+	case error:
+		return dm.maskError(st, v)
+	case fmt.Formatter:
+		return v
+	case fmt.Stringer:
+		return dm.maskStringer(st, v)
+	}
+
+	switch reflect.ValueOf(arg).Kind() {
+	case reflect.Struct, reflect.Ptr, reflect.Map, reflect.Slice, reflect.Array:
+		switch arg.(type) {
+		case []byte, map[string]interface{}, []interface{}, []map[string]interface{}, map[interface{}]interface{}:
+			return dm.processValue(st, arg)
+		}
+		if dm.strict {
+			return dm.processStrict(st, arg)
+		}
+		return dm.maskReflect(st, reflect.ValueOf(arg)).Interface()
+	default:
+		return dm.processValue(st, arg)
+	}
+}
+
+// maskReflect returns a copy of v of the same type with its strings masked,
+// so that verbs such as %+v print the original shape. Unexported fields are
+// copied as they are; the formatted output goes through the patterns anyway.
+func (dm *DefaultMasker) maskReflect(st walkState, v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		var masked string
+		if st.sensitive {
+			masked = dm.redactValue(st, s).(string)
+		} else {
+			masked = dm.maskString(st, s)
+		}
+		if masked == s {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.SetString(masked)
+		return result
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		if st.sensitive && !v.IsZero() {
+			st.recordKey()
+			return reflect.Zero(v.Type())
+		}
+		return v
+	case reflect.Struct:
+		result := reflect.New(v.Type()).Elem()
+		result.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			result.Field(i).Set(dm.maskReflect(dm.keyState(st, fieldKey(field)), v.Field(i)))
+		}
+		return result
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		result := reflect.New(v.Type()).Elem()
+		result.Set(dm.maskReflect(st, v.Elem()))
+		return result
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		child, id, ok := dm.enterContainer(st, v.Interface())
+		if !ok {
+			return reflect.Zero(v.Type())
+		}
+		defer child.leaveContainer(id)
+		return dm.maskReflectContainer(child, v)
+	case reflect.Array:
+		result := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(dm.maskReflect(st.childIndex(i), v.Index(i)))
+		}
+		return result
+	default:
+		return v
+	}
+}
+
+func (dm *DefaultMasker) maskReflectContainer(st walkState, v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Ptr:
+		result := reflect.New(v.Type().Elem())
+		result.Elem().Set(dm.maskReflect(st, v.Elem()))
+		return result
+	case reflect.Map:
+		result := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			child := st.childKey(iter.Key().Interface())
+			if iter.Key().Kind() == reflect.String {
+				child = dm.keyState(st, iter.Key().String())
+			}
+			result.SetMapIndex(iter.Key(), dm.maskReflect(child, iter.Value()))
+		}
+		return result
+	default:
+		if v.Type().Elem() == reflect.TypeOf(byte(0)) {
+			b := v.Bytes()
+			var masked []byte
+			if st.sensitive {
+				masked = dm.redactValue(st, b).([]byte)
+			} else {
+				masked = dm.maskBytes(st, b)
+			}
+			return reflect.ValueOf(masked).Convert(v.Type())
+		}
+		result := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			result.Index(i).Set(dm.maskReflect(st.childIndex(i), v.Index(i)))
+		}
+		return result
+	}
+}
+
+// fieldKey returns the name a struct field is known by in JSON, which is
+// what the sensitive key rules are usually written against.
+func fieldKey(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
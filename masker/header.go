@@ -0,0 +1,88 @@
+package masker
+
+import (
+	"net/http"
+	"strings"
+)
+
+func MaskHeader(header http.Header, opts ...Option) http.Header {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskHeader(header)
+}
+
+// MaskHeader returns a masked copy of header. Credentials in Authorization
+// and Proxy-Authorization are masked after the scheme, every cookie value in
+// Cookie and Set-Cookie is masked, well-known credential headers such as
+// X-Api-Key and headers matching the sensitive key rules are masked entirely
+// and all other values go through the patterns.
+func (dm *DefaultMasker) MaskHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+
+	st := dm.newWalk(nil)
+	result := make(http.Header, len(header))
+	for name, values := range header {
+		masked := make([]string, len(values))
+		for i, value := range values {
+			masked[i] = dm.maskHeaderValue(st, name, value)
+		}
+		result[name] = masked
+	}
+	return result
+}
+
+func (dm *DefaultMasker) maskHeaderValue(st walkState, name, value string) string {
+	if value == "" {
+		return value
+	}
+
+	switch http.CanonicalHeaderKey(name) {
+	case "Authorization", "Proxy-Authorization":
+		if loc := authSchemeRegex.FindStringIndex(value); loc != nil {
+			return value[:loc[1]] + dm.keyMaskFunc(value[loc[1]:])
+		}
+		if scheme, credentials, found := strings.Cut(value, " "); found && credentials != "" {
+			return scheme + " " + dm.keyMaskFunc(credentials)
+		}
+		return dm.keyMaskFunc(value)
+	case "Cookie":
+		return maskCookieHeader(value, false, dm.keyMaskFunc)
+	case "Set-Cookie":
+		return maskCookieHeader(value, true, dm.keyMaskFunc)
+	case "X-Api-Key", "X-Auth-Token", "X-Access-Token", "X-Csrf-Token", "X-Xsrf-Token",
+		"X-Amz-Security-Token", "X-Goog-Api-Key", "Api-Key":
+		return dm.keyMaskFunc(value)
+	}
+
+	if IsSensitiveKey(name, dm.keyRules()) {
+		return dm.keyMaskFunc(value)
+	}
+	return dm.maskString(st, value)
+}
+
+// maskCookieHeader masks the value of every name=value pair of a Cookie
+// header, or only the first one of a Set-Cookie header, whose other pairs
+// are attributes.
+func maskCookieHeader(value string, setCookie bool, maskFunc func(string) string) string {
+	if setCookie {
+		cookie, attributes, found := strings.Cut(value, ";")
+		if found {
+			return maskCookie(cookie, maskFunc) + ";" + attributes
+		}
+		return maskCookie(cookie, maskFunc)
+	}
+
+	cookies := strings.Split(value, ";")
+	for i, cookie := range cookies {
+		cookies[i] = maskCookie(cookie, maskFunc)
+	}
+	return strings.Join(cookies, ";")
+}
+
+func maskCookie(cookie string, maskFunc func(string) string) string {
+	name, value, found := strings.Cut(cookie, "=")
+	if !found || strings.TrimSpace(value) == "" {
+		return cookie
+	}
+	return name + "=" + maskFunc(value)
+}
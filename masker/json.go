@@ -0,0 +1,40 @@
+package masker
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+func MaskJSON(data []byte, opts ...Option) ([]byte, error) {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskJSON(data)
+}
+
+// MaskJSON decodes a JSON document, masks it like Mask and encodes it again.
+// Numbers keep their original precision; object keys come out sorted. Any
+// data after the first value other than whitespace is an error.
+func (dm *DefaultMasker) MaskJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	var extra json.RawMessage
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		if err == nil {
+			err = errors.New("masker: unexpected data after the JSON value")
+		}
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(dm.Mask(value)); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,205 @@
+package masker
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	SensitiveKeyPattern     = "sensitive_key"
+	DefaultKeyValuePriority = 60
+
+	minLooseKeyRule = 5
+)
+
+var authSchemeRegex = regexp.MustCompile(`(?i)^(?:basic|bearer|digest|token|negotiate|ntlm)\s+`)
+
+func DefaultSensitiveKeys() []string {
+	return []string{
+		"password", "passwd", "pwd", "senha", "secret", "token",
+		"apikey", "accesskey", "privatekey", "clientsecret",
+		"authorization", "cookie", "credential", "credentials",
+		"cvv", "cvc", "pin",
+	}
+}
+
+// IsSensitiveKey reports whether key matches one of the rules. Keys and rules
+// are compared case-insensitively with '_', '-', '.' and spaces removed. A
+// rule matches when the key equals it or ends with it; rules shorter than
+// minLooseKeyRule only match a suffix that starts a word of the key, after a
+// separator or at a camel-case boundary, so "user_pin" and "userPin" match
+// "pin" but "spin" does not.
+func IsSensitiveKey(key string, rules []string) bool {
+	normalized, wordStarts := splitKey(key)
+	if normalized == "" {
+		return false
+	}
+	for _, rule := range rules {
+		rule = normalizeKey(rule)
+		if rule == "" || !strings.HasSuffix(normalized, rule) {
+			continue
+		}
+		if start := len(normalized) - len(rule); start == 0 || len(rule) >= minLooseKeyRule || wordStarts[start] {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeKey(key string) string {
+	normalized, _ := splitKey(key)
+	return normalized
+}
+
+// splitKey returns the normalized key and, for each of its byte offsets,
+// whether a word of the original key starts there.
+func splitKey(key string) (string, []bool) {
+	var b strings.Builder
+	b.Grow(len(key))
+	runes := []rune(key)
+	wordStarts := make([]bool, 0, len(key))
+	separated := false
+	for i, r := range runes {
+		switch r {
+		case '_', '-', '.', ' ':
+			separated = true
+			continue
+		}
+		start := separated || i > 0 && isCamelBoundary(runes, i)
+		separated = false
+		b.WriteRune(unicode.ToLower(r))
+		for len(wordStarts) < b.Len() {
+			wordStarts = append(wordStarts, start)
+			start = false
+		}
+	}
+	return b.String(), wordStarts
+}
+
+// isCamelBoundary reports whether runes[i] starts a word, as in userPin or
+// the K of APIKey.
+func isCamelBoundary(runes []rune, i int) bool {
+	if !unicode.IsUpper(runes[i]) {
+		return false
+	}
+	prev := runes[i-1]
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1])
+}
+
+func KeyValuePattern(keys []string, maskFunc func(string) string) Pattern {
+	if len(keys) == 0 {
+		keys = DefaultSensitiveKeys()
+	}
+	if maskFunc == nil {
+		maskFunc = Redact
+	}
+
+	alternatives := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key := normalizeKey(key); key != "" {
+			alternatives = append(alternatives, keyRuleRegex(key))
+		}
+	}
+
+	// Cookie headers hold a list of pairs, each masked on its own like
+	// MaskHeader does, so they are matched up to the end of the line.
+	cookies, offset := "", 0
+	if IsSensitiveKey("cookie", keys) {
+		cookies, offset = `\b((?:set-)?cookie)(\s*:[ \t]*)([^\s"'][^\r\n]*)|`, 3
+	}
+	re := regexp.MustCompile(`(?i)` + cookies +
+		`\b([A-Za-z0-9_.\-]*(?:` + strings.Join(alternatives, "|") + `))` +
+		`(["']?\s*[:=]\s*)` +
+		`(?:"([^"]*)"|'([^']*)'|((?:basic|bearer|digest|token|negotiate|ntlm)\s+[^\s"',;&}\]]+|[^\s"',;&}\]]+))`)
+
+	mask := func(s string) (string, bool) {
+		loc := re.FindStringSubmatchIndex(s)
+		if loc == nil {
+			return s, false
+		}
+		if offset > 0 && loc[6] >= 0 {
+			start, end := loc[6], loc[7]
+			masked := maskCookieHeader(s[start:end], loc[3]-loc[2] > len("cookie"), maskFunc)
+			return s[:start] + masked + s[end:], masked != s[start:end]
+		}
+		key := (1 + offset) * 2
+		if !IsSensitiveKey(s[loc[key]:loc[key+1]], keys) {
+			return s, false
+		}
+		for group := 3 + offset; group <= 5+offset; group++ {
+			start, end := loc[group*2], loc[group*2+1]
+			if start < 0 {
+				continue
+			}
+			if scheme := authSchemeRegex.FindStringIndex(s[start:end]); scheme != nil {
+				start += scheme[1]
+			}
+			if start >= end {
+				return s, false
+			}
+			return s[:start] + maskFunc(s[start:end]) + s[end:], true
+		}
+		return s, false
+	}
+
+	return Pattern{
+		Name:     SensitiveKeyPattern,
+		Regex:    re.String(),
+		Priority: DefaultKeyValuePriority,
+		Severity: SeverityHigh,
+		Validate: func(s string) bool {
+			_, ok := mask(s)
+			return ok
+		},
+		MaskFunc: func(s string) string {
+			masked, _ := mask(s)
+			return masked
+		},
+	}
+}
+
+func keyRuleRegex(rule string) string {
+	parts := make([]string, 0, len(rule))
+	for _, r := range rule {
+		parts = append(parts, regexp.QuoteMeta(string(r)))
+	}
+	return strings.Join(parts, `[_.\- ]?`)
+}
+
+func (dm *DefaultMasker) isSensitiveKey(key string) bool {
+	return len(dm.sensitiveKeys) > 0 && IsSensitiveKey(key, dm.sensitiveKeys)
+}
+
+func (dm *DefaultMasker) keyState(st walkState, key interface{}) walkState {
+	child := st.childKey(key)
+	if k, ok := key.(string); ok && dm.isSensitiveKey(k) {
+		child.sensitive = true
+	}
+	return child
+}
+
+func (dm *DefaultMasker) redactValue(st walkState, value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return v
+		}
+		st.recordKey()
+		return dm.keyMaskFunc(v)
+	case []byte:
+		if len(v) == 0 {
+			return v
+		}
+		st.recordKey()
+		return []byte(dm.keyMaskFunc(string(v)))
+	}
+	st.recordKey()
+	if _, _, numeric := numericString(value); numeric && dm.numericSentinel != nil {
+		return dm.numericSentinel
+	}
+	return RedactedValue
+}
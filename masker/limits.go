@@ -0,0 +1,131 @@
+package masker
+
+import (
+	"reflect"
+	"time"
+)
+
+const (
+	LimitPattern     = "limit"
+	TruncatedValue   = "[TRUNCATED]"
+	DefaultMaxDepth  = 1000
+	reasonCycle      = "cycle detected"
+	reasonMaxDepth   = "max depth exceeded"
+	reasonMaxElement = "max elements exceeded"
+)
+
+type LimitPolicy int
+
+const (
+	// LimitTruncate replaces whatever is beyond the limit with TruncatedValue
+	// and keeps the part that was already processed.
+	LimitTruncate LimitPolicy = iota
+	// LimitRedact replaces the whole value that hit the limit with
+	// RedactedValue.
+	LimitRedact
+)
+
+type walkShared struct {
+	visited  map[containerID]bool
+	elements int
+	deadline time.Time
+}
+
+type containerID struct {
+	ptr uintptr
+	len int
+}
+
+func (dm *DefaultMasker) newWalk(report *Report) walkState {
+	shared := &walkShared{}
+	if dm.timeBudget > 0 {
+		shared.deadline = time.Now().Add(dm.timeBudget)
+	}
+	return walkState{report: report, shared: shared}
+}
+
+func (dm *DefaultMasker) limitMarker() string {
+	if dm.limitPolicy == LimitRedact {
+		return RedactedValue
+	}
+	return TruncatedValue
+}
+
+func (dm *DefaultMasker) countElement(st walkState) bool {
+	if dm.maxElements <= 0 || st.shared == nil {
+		return true
+	}
+	st.shared.elements++
+	if st.shared.elements > dm.maxElements {
+		st.recordLimit(reasonMaxElement)
+		return false
+	}
+	return true
+}
+
+func (dm *DefaultMasker) exhausted(st walkState) bool {
+	return dm.maxElements > 0 && st.shared != nil && st.shared.elements >= dm.maxElements
+}
+
+func (dm *DefaultMasker) enterContainer(st walkState, container interface{}) (walkState, containerID, bool) {
+	if st.depth >= dm.maxDepth {
+		st.recordLimit(reasonMaxDepth)
+		return st, containerID{}, false
+	}
+	st.depth++
+
+	rv := reflect.ValueOf(container)
+	id := containerID{ptr: rv.Pointer()}
+	if rv.Kind() == reflect.Slice {
+		id.len = rv.Len()
+	}
+	if id.ptr == 0 || st.shared == nil {
+		return st, containerID{}, true
+	}
+
+	if st.shared.visited == nil {
+		st.shared.visited = make(map[containerID]bool)
+	}
+	if st.shared.visited[id] {
+		st.recordLimit(reasonCycle)
+		return st, containerID{}, false
+	}
+	st.shared.visited[id] = true
+	return st, id, true
+}
+
+func (st walkState) leaveContainer(id containerID) {
+	if id.ptr != 0 {
+		delete(st.shared.visited, id)
+	}
+}
+
+func (dm *DefaultMasker) processPointer(st walkState, rv reflect.Value) interface{} {
+	if rv.IsNil() {
+		return rv.Interface()
+	}
+
+	elem := rv.Elem().Interface()
+	switch elem.(type) {
+	case string, []byte, map[string]interface{}, []interface{}, []map[string]interface{}, map[interface{}]interface{}:
+	default:
+		if dm.strict {
+			return dm.processStrict(st, rv.Interface())
+		}
+		return rv.Interface()
+	}
+
+	child, id, ok := dm.enterContainer(st, rv.Interface())
+	if !ok {
+		return dm.limitMarker()
+	}
+	defer child.leaveContainer(id)
+
+	if s, isString := elem.(string); isString {
+		if masked := dm.processValue(child, s); masked != s {
+			return masked
+		}
+		return rv.Interface()
+	}
+	return dm.processValue(child, elem)
+}
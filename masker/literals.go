@@ -0,0 +1,427 @@
+package masker
+
+import (
+	"regexp"
+	"regexp/syntax"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	minLiteralPrefix   = 2
+	maxLiteralPrefixes = 64
+	maxClassPrefixes   = 8
+	maxSubsetRegexps   = 128
+)
+
+// literalIndex records, for the patterns whose every match starts with one
+// of a few literal prefixes (AKIA, ghp_, -----BEGIN, ...), where those
+// prefixes occur in a string. Patterns whose prefixes do not occur are
+// skipped and the others only run from their first occurrence on.
+//
+// Prefixes and inputs are compared with ASCII letters lowercased, which
+// keeps offsets stable; case-insensitive literals are expanded to every
+// non-ASCII rune they fold to.
+type literalIndex struct {
+	ac          *ahoCorasick
+	keyPatterns [][]int
+	prefixed    []bool
+	count       int
+
+	// subsetSafe reports whether the combined regex keeps its meaning when
+	// alternatives are left out, i.e. no pattern leaks flags into the next.
+	subsetSafe bool
+	subsets    sync.Map
+	nsubsets   atomic.Int32
+}
+
+func newLiteralIndex(patterns []compiledPattern) *literalIndex {
+	li := &literalIndex{prefixed: make([]bool, len(patterns))}
+
+	keys := make(map[string]int)
+	var keyList []string
+	for i, cp := range patterns {
+		prefixes := literalPrefixes(cp.Regex)
+		if len(prefixes) == 0 {
+			continue
+		}
+		li.prefixed[i] = true
+		li.count++
+		for _, prefix := range prefixes {
+			k, ok := keys[prefix]
+			if !ok {
+				k = len(keyList)
+				keys[prefix] = k
+				keyList = append(keyList, prefix)
+				li.keyPatterns = append(li.keyPatterns, nil)
+			}
+			li.keyPatterns[k] = append(li.keyPatterns[k], i)
+		}
+	}
+	if li.count == 0 {
+		return nil
+	}
+	li.ac = newAhoCorasick(keyList)
+
+	parts := make([]string, len(patterns))
+	for i, cp := range patterns {
+		parts[i] = cp.Regex
+	}
+	li.subsetSafe = sameRegex(strings.Join(parts, "|"), "(?:"+strings.Join(parts, ")|(?:")+")")
+
+	return li
+}
+
+// firstPositions sets first[i] to the offset of the earliest prefix of
+// pattern i in s, -1 when there is none, or 0 for patterns without
+// prefixes. It reports whether any pattern can match.
+func (li *literalIndex) firstPositions(s string, first []int) bool {
+	pending := li.count
+	for i := range first {
+		if li.prefixed[i] {
+			first[i] = -1
+		} else {
+			first[i] = 0
+		}
+	}
+
+	// Occurrences come ordered by end offset, so once every pattern has one
+	// the scan goes on until no later occurrence can start before them.
+	bound := -1
+	li.ac.scan(s, func(key, start, end int) bool {
+		for _, i := range li.keyPatterns[key] {
+			switch {
+			case first[i] < 0:
+				first[i] = start
+				bound = max(bound, start)
+				pending--
+			case start < first[i]:
+				first[i] = start
+			}
+		}
+		return pending > 0 || end-li.ac.maxLen < bound
+	})
+
+	return pending < li.count || li.count < len(first)
+}
+
+// subsetRegex returns the combined regex of the patterns with a first
+// position, or nil when the full combined regex has to be used.
+func (li *literalIndex) subsetRegex(patterns []compiledPattern, first []int) *regexp.Regexp {
+	if !li.subsetSafe {
+		return nil
+	}
+
+	var key strings.Builder
+	var parts []string
+	for i, cp := range patterns {
+		if first[i] >= 0 {
+			key.WriteByte('1')
+			parts = append(parts, cp.Regex)
+		} else {
+			key.WriteByte('0')
+		}
+	}
+	if len(parts) == len(patterns) {
+		return nil
+	}
+
+	if re, ok := li.subsets.Load(key.String()); ok {
+		return re.(*regexp.Regexp)
+	}
+	if li.nsubsets.Load() >= maxSubsetRegexps {
+		return nil
+	}
+	re, err := regexp.Compile(strings.Join(parts, "|"))
+	if err != nil {
+		return nil
+	}
+	if _, loaded := li.subsets.LoadOrStore(key.String(), re); !loaded {
+		li.nsubsets.Add(1)
+	}
+	return re
+}
+
+// candidates fills first from buf, or allocates it when there are more
+// patterns than buf holds. It returns nil when no pattern has prefixes.
+func (dm *DefaultMasker) candidates(s string, buf []int) ([]int, bool) {
+	if dm.literals == nil {
+		return nil, true
+	}
+	first := buf
+	if len(dm.compiledPatterns) > len(buf) {
+		first = make([]int, len(dm.compiledPatterns))
+	}
+	first = first[:len(dm.compiledPatterns)]
+	return first, dm.literals.firstPositions(s, first)
+}
+
+// scanStart returns where the search for matches starting at or after
+// first has to begin. It includes the preceding rune so that assertions
+// such as \b still see it.
+func scanStart(s string, first int) int {
+	return runeFloor(s, first-1)
+}
+
+func sameRegex(a, b string) bool {
+	ra, err := syntax.Parse(a, syntax.Perl)
+	if err != nil {
+		return false
+	}
+	rb, err := syntax.Parse(b, syntax.Perl)
+	if err != nil {
+		return false
+	}
+	return ra.Simplify().String() == rb.Simplify().String()
+}
+
+// literalPrefixes returns the literal strings every match of expr starts
+// with, normalized by lowerASCII, or nil if there is no useful set.
+func literalPrefixes(expr string) []string {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil
+	}
+	prefixes, _, ok := prefixSet(re.Simplify())
+	if !ok {
+		return nil
+	}
+	for _, prefix := range prefixes {
+		if len(prefix) < minLiteralPrefix {
+			return nil
+		}
+	}
+	return prefixes
+}
+
+// prefixSet returns the prefixes of re and whether they are complete, i.e.
+// re matches exactly those strings and a following expression may extend
+// them.
+func prefixSet(re *syntax.Regexp) ([]string, bool, bool) {
+	switch re.Op {
+	case syntax.OpEmptyMatch:
+		return []string{""}, true, true
+	case syntax.OpLiteral:
+		set := []string{""}
+		for _, r := range re.Rune {
+			runes := []rune{r}
+			if re.Flags&syntax.FoldCase != 0 {
+				runes = foldOrbit(r)
+			}
+			var ok bool
+			if set, ok = crossPrefixes(set, runeStrings(runes)); !ok {
+				return set, false, len(set[0]) > 0
+			}
+		}
+		return set, true, true
+	case syntax.OpCharClass:
+		var runes []rune
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if len(runes)+int(re.Rune[i+1]-re.Rune[i]) >= maxClassPrefixes {
+				return nil, false, false
+			}
+			for r := re.Rune[i]; r <= re.Rune[i+1]; r++ {
+				runes = append(runes, r)
+			}
+		}
+		if len(runes) == 0 {
+			return nil, false, false
+		}
+		return runeStrings(runes), true, true
+	case syntax.OpCapture:
+		return prefixSet(re.Sub[0])
+	case syntax.OpPlus:
+		set, _, ok := prefixSet(re.Sub[0])
+		return set, false, ok
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return nil, false, false
+		}
+		set, _, ok := prefixSet(re.Sub[0])
+		return set, false, ok
+	case syntax.OpAlternate:
+		var set []string
+		complete := true
+		for _, sub := range re.Sub {
+			subSet, subComplete, ok := prefixSet(sub)
+			if !ok {
+				return nil, false, false
+			}
+			set = append(set, subSet...)
+			complete = complete && subComplete
+		}
+		if len(set) > maxLiteralPrefixes {
+			return nil, false, false
+		}
+		return dedupe(set), complete, true
+	case syntax.OpConcat:
+		set := []string{""}
+		for _, sub := range re.Sub {
+			if isEmptyWidth(sub.Op) {
+				if len(set) == 1 && set[0] == "" {
+					continue
+				}
+				return set, false, true
+			}
+			subSet, subComplete, ok := prefixSet(sub)
+			if !ok {
+				return set, false, set[0] != ""
+			}
+			crossed, ok := crossPrefixes(set, subSet)
+			if !ok {
+				return set, false, set[0] != ""
+			}
+			set = crossed
+			if !subComplete {
+				return set, false, true
+			}
+		}
+		return set, true, true
+	}
+	return nil, false, false
+}
+
+func isEmptyWidth(op syntax.Op) bool {
+	switch op {
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText, syntax.OpEndText,
+		syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return true
+	}
+	return false
+}
+
+func crossPrefixes(set, next []string) ([]string, bool) {
+	if len(set)*len(next) > maxLiteralPrefixes {
+		return set, false
+	}
+	crossed := make([]string, 0, len(set)*len(next))
+	for _, a := range set {
+		for _, b := range next {
+			crossed = append(crossed, a+b)
+		}
+	}
+	return dedupe(crossed), true
+}
+
+func foldOrbit(r rune) []rune {
+	runes := []rune{r}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		runes = append(runes, f)
+	}
+	return runes
+}
+
+func runeStrings(runes []rune) []string {
+	set := make([]string, 0, len(runes))
+	for _, r := range runes {
+		if !utf8.ValidRune(r) {
+			continue
+		}
+		set = append(set, lowerASCII(string(r)))
+	}
+	return dedupe(set)
+}
+
+func dedupe(set []string) []string {
+	seen := make(map[string]bool, len(set))
+	out := set[:0]
+	for _, s := range set {
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func lowerASCII(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		b[i] = toLowerASCII(c)
+	}
+	return string(b)
+}
+
+func toLowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + 'a' - 'A'
+	}
+	return c
+}
+
+// ahoCorasick is a byte-level Aho–Corasick automaton compiled to a full
+// transition table.
+type ahoCorasick struct {
+	next   [][256]int32
+	output [][]int32
+	keyLen []int
+	maxLen int
+}
+
+func newAhoCorasick(keys []string) *ahoCorasick {
+	ac := &ahoCorasick{next: make([][256]int32, 1), output: make([][]int32, 1)}
+	for i := range ac.next[0] {
+		ac.next[0][i] = -1
+	}
+
+	for k, key := range keys {
+		state := int32(0)
+		for i := 0; i < len(key); i++ {
+			c := key[i]
+			if ac.next[state][c] < 0 {
+				var row [256]int32
+				for j := range row {
+					row[j] = -1
+				}
+				ac.next = append(ac.next, row)
+				ac.output = append(ac.output, nil)
+				ac.next[state][c] = int32(len(ac.next) - 1)
+			}
+			state = ac.next[state][c]
+		}
+		ac.output[state] = append(ac.output[state], int32(k))
+		ac.keyLen = append(ac.keyLen, len(key))
+		ac.maxLen = max(ac.maxLen, len(key))
+	}
+
+	fail := make([]int32, len(ac.next))
+	var queue []int32
+	for c := range ac.next[0] {
+		if s := ac.next[0][c]; s > 0 {
+			queue = append(queue, s)
+		} else {
+			ac.next[0][c] = 0
+		}
+	}
+	for len(queue) > 0 {
+		state := queue[0]
+		queue = queue[1:]
+		ac.output[state] = append(ac.output[state], ac.output[fail[state]]...)
+		for c := range ac.next[state] {
+			if s := ac.next[state][c]; s >= 0 {
+				fail[s] = ac.next[fail[state]][c]
+				queue = append(queue, s)
+			} else {
+				ac.next[state][c] = ac.next[fail[state]][c]
+			}
+		}
+	}
+	return ac
+}
+
+// scan calls visit with the start and end offsets of every key occurrence
+// in s, ordered by end offset, until visit returns false.
+func (ac *ahoCorasick) scan(s string, visit func(key, start, end int) bool) {
+	state := int32(0)
+	for i := 0; i < len(s); i++ {
+		state = ac.next[state][toLowerASCII(s[i])]
+		for _, k := range ac.output[state] {
+			if !visit(int(k), i+1-ac.keyLen[k], i+1) {
+				return
+			}
+		}
+	}
+}
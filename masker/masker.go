@@ -2,9 +2,12 @@ package masker
 
 import (
 	"encoding/json"
+	"fmt"
+	"reflect"
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Masker interface {
@@ -12,11 +15,34 @@ type Masker interface {
 	MaskInterface(data interface{}) interface{}
 }
 
+// ReportingMasker is implemented by maskers that can also report what they
+// masked, such as DefaultMasker and ReloadableMasker.
+type ReportingMasker interface {
+	Masker
+	MaskWithReport(data interface{}) (interface{}, *Report)
+}
+
 type DefaultMasker struct {
-	patterns []Pattern
-	cache    *regexp.Regexp
-	mu       sync.RWMutex
-	compiled bool
+	patterns         []Pattern
+	compiledPatterns []compiledPattern
+	overlapMode      OverlapMode
+	minConfidence    float64
+	sensitiveKeys    []string
+	keyMaskFunc      func(string) string
+	numericKinds     NumericKind
+	numericSentinel  interface{}
+	maskStringers    bool
+	maxDepth         int
+	maxElements      int
+	limitPolicy      LimitPolicy
+	maxStringLength  int
+	timeBudget       time.Duration
+	strict           bool
+	cache            *regexp.Regexp
+	prefilter        *prefilter
+	literals         *literalIndex
+	mu               sync.RWMutex
+	compiled         bool
 }
 
 func NewWithOpts(opts ...Option) Masker {
@@ -32,20 +58,48 @@ func NewWithOpts(opts ...Option) Masker {
 		opt(&config)
 	}
 
-	masker := &DefaultMasker{
-		patterns: config.Patterns,
-	}
-	masker.compilePatterns()
-
-	return masker
+	return newFromConfig(config)
 }
 
 func New() Masker {
+	return newFromConfig(DefaultConfig())
+}
+
+func newFromConfig(config Config) *DefaultMasker {
+	keyMaskFunc := config.KeyMaskFunc
+	if keyMaskFunc == nil {
+		keyMaskFunc = Redact
+	}
+
+	patterns := config.Patterns
+	if config.KeyValueDetection {
+		keys := config.SensitiveKeys
+		if len(keys) == 0 {
+			keys = DefaultSensitiveKeys()
+		}
+		patterns = append(patterns[:len(patterns):len(patterns)], KeyValuePattern(keys, keyMaskFunc))
+	}
 
-	config := DefaultConfig()
 	masker := &DefaultMasker{
-		patterns: config.Patterns,
+		patterns:        patterns,
+		overlapMode:     config.OverlapMode,
+		minConfidence:   config.MinConfidence,
+		sensitiveKeys:   config.SensitiveKeys,
+		keyMaskFunc:     keyMaskFunc,
+		numericKinds:    config.NumericKinds,
+		numericSentinel: config.NumericSentinel,
+		maskStringers:   config.MaskStringers,
+		maxDepth:        config.MaxDepth,
+		maxElements:     config.MaxElements,
+		limitPolicy:     config.LimitPolicy,
+		maxStringLength: config.MaxStringLength,
+		timeBudget:      config.TimeBudget,
+		strict:          config.StrictMode,
 	}
+	if masker.maxDepth <= 0 {
+		masker.maxDepth = DefaultMaxDepth
+	}
+	masker.lintPatterns(config.OnWarning)
 	masker.compilePatterns()
 
 	return masker
@@ -65,69 +119,148 @@ func (dm *DefaultMasker) compilePatterns() {
 		if len(regexParts) > 0 {
 			combinedRegex := strings.Join(regexParts, "|")
 			dm.cache = regexp.MustCompile(combinedRegex)
+			dm.compiledPatterns = compilePatternList(dm.patterns)
+			dm.prefilter = newPrefilter(dm.compiledPatterns)
+			dm.literals = newLiteralIndex(dm.compiledPatterns)
 			dm.compiled = true
 		}
 	}
 }
 
 func (dm *DefaultMasker) Mask(data interface{}) interface{} {
-	return dm.processValue(data)
+	return dm.processValue(dm.newWalk(nil), data)
 }
 
 func (dm *DefaultMasker) MaskInterface(data interface{}) interface{} {
-	return dm.processInterface(data)
+	return dm.processInterface(dm.newWalk(nil), data)
+}
+
+func (dm *DefaultMasker) MaskWithReport(data interface{}) (interface{}, *Report) {
+	report := &Report{}
+	return dm.processValue(dm.newWalk(report), data), report
 }
 
-func (dm *DefaultMasker) processValue(value interface{}) interface{} {
+func (dm *DefaultMasker) processValue(st walkState, value interface{}) interface{} {
+	if !dm.countElement(st) {
+		return dm.limitMarker()
+	}
+
 	switch v := value.(type) {
 	case string:
-		return dm.maskString(v)
+		if st.sensitive {
+			return dm.redactValue(st, v)
+		}
+		return dm.maskString(st, v)
+	case []byte:
+		if st.sensitive {
+			return dm.redactValue(st, v)
+		}
+		return dm.maskBytes(st, v)
+	case map[string]interface{}, []interface{}, []map[string]interface{}, map[interface{}]interface{}:
+		child, id, ok := dm.enterContainer(st, v)
+		if !ok {
+			return dm.limitMarker()
+		}
+		defer child.leaveContainer(id)
+		return dm.processContainer(child, v)
+	case nil:
+		return nil
+	default:
+		if st.sensitive {
+			return dm.redactValue(st, v)
+		}
+		if s, kind, ok := numericString(v); ok {
+			return dm.processNumber(st, v, s, kind)
+		}
+		if err, ok := v.(error); ok {
+			return dm.maskError(st, err)
+		}
+		if stringer, ok := v.(fmt.Stringer); ok && dm.maskStringers {
+			return dm.maskStringer(st, stringer)
+		}
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr {
+			return dm.processPointer(st, rv)
+		}
+		if dm.strict {
+			return dm.processStrict(st, v)
+		}
+		return v
+	}
+}
+
+func (dm *DefaultMasker) processContainer(st walkState, value interface{}) interface{} {
+	switch v := value.(type) {
 	case map[string]interface{}:
-		return dm.processMap(v)
+		return dm.processMap(st, v)
 	case []interface{}:
-		return dm.processSlice(v)
+		return dm.processSlice(st, v)
 	case []map[string]interface{}:
-		return dm.processMapSlice(v)
+		return dm.processMapSlice(st, v)
 	case map[interface{}]interface{}:
-		return dm.processInterfaceMap(v)
+		return dm.processInterfaceMap(st, v)
 	default:
 		return v
 	}
 }
 
-func (dm *DefaultMasker) processMap(m map[string]interface{}) map[string]interface{} {
+func (dm *DefaultMasker) processMap(st walkState, m map[string]interface{}) map[string]interface{} {
 	result := make(map[string]interface{})
+	truncated := false
 	for key, value := range m {
-		result[key] = dm.processValue(value)
+		if dm.exhausted(st) {
+			if !truncated {
+				st.recordLimit(reasonMaxElement)
+				truncated = true
+			}
+			result[key] = dm.limitMarker()
+			continue
+		}
+		result[key] = dm.processValue(dm.keyState(st, key), value)
 	}
 	return result
 }
 
-func (dm *DefaultMasker) processInterfaceMap(m map[interface{}]interface{}) map[interface{}]interface{} {
+func (dm *DefaultMasker) processInterfaceMap(st walkState, m map[interface{}]interface{}) map[interface{}]interface{} {
 	result := make(map[interface{}]interface{})
+	truncated := false
 	for key, value := range m {
-		result[key] = dm.processValue(value)
+		if dm.exhausted(st) {
+			if !truncated {
+				st.recordLimit(reasonMaxElement)
+				truncated = true
+			}
+			result[key] = dm.limitMarker()
+			continue
+		}
+		result[key] = dm.processValue(dm.keyState(st, key), value)
 	}
 	return result
 }
 
-func (dm *DefaultMasker) processSlice(s []interface{}) []interface{} {
+func (dm *DefaultMasker) processSlice(st walkState, s []interface{}) []interface{} {
 	result := make([]interface{}, len(s))
 	for i, item := range s {
-		result[i] = dm.processValue(item)
+		if dm.exhausted(st) {
+			st.recordLimit(reasonMaxElement)
+			if dm.limitPolicy == LimitRedact {
+				return []interface{}{RedactedValue}
+			}
+			return append(result[:i], TruncatedValue)
+		}
+		result[i] = dm.processValue(st.childIndex(i), item)
 	}
 	return result
 }
 
-func (dm *DefaultMasker) processMapSlice(s []map[string]interface{}) []map[string]interface{} {
+func (dm *DefaultMasker) processMapSlice(st walkState, s []map[string]interface{}) []map[string]interface{} {
 	result := make([]map[string]interface{}, len(s))
 	for i, item := range s {
-		result[i] = dm.processMap(item)
+		result[i] = dm.processMap(st.childIndex(i), item)
 	}
 	return result
 }
 
-func (dm *DefaultMasker) maskString(s string) string {
+func (dm *DefaultMasker) maskString(st walkState, s string) string {
 	if !dm.compiled {
 		return s
 	}
@@ -135,25 +268,35 @@ func (dm *DefaultMasker) maskString(s string) string {
 	dm.mu.RLock()
 	defer dm.mu.RUnlock()
 
-	return dm.cache.ReplaceAllStringFunc(s, func(match string) string {
-		for _, pattern := range dm.patterns {
-			if pattern.MaskFunc != nil {
-				compiledPattern := regexp.MustCompile(pattern.Regex)
-				if compiledPattern.MatchString(match) {
-					return pattern.MaskFunc(match)
-				}
-			}
-		}
-		return match
-	})
+	if dm.maxStringLength > 0 && len(s) > dm.maxStringLength {
+		return dm.failClosed(st, reasonMaxStringLength)
+	}
+	if st.budgetExceeded() {
+		return dm.failClosed(st, reasonTimeBudget)
+	}
+	if !dm.prefilter.mayMatchString(s) {
+		return s
+	}
+
+	matches := dm.findMatches(st, s)
+	if st.budgetExceeded() {
+		return dm.failClosed(st, reasonTimeBudget)
+	}
+	for _, m := range matches {
+		st.record(m, false, "")
+	}
+	return applyMatches(s, matches)
 }
 
-func (dm *DefaultMasker) processInterface(v interface{}) map[string]interface{} {
-	data, _ := StructToMap(v)
+func (dm *DefaultMasker) processInterface(st walkState, v interface{}) interface{} {
+	data, err := StructToMap(v)
+	if err != nil && dm.strict {
+		return dm.unsupported(st, err.Error())
+	}
 
 	result := make(map[string]interface{})
 	for key, value := range data {
-		result[key] = dm.processValue(value)
+		result[key] = dm.processValue(dm.keyState(st, key), value)
 	}
 	return result
 }
@@ -0,0 +1,224 @@
+package masker
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type compiledPattern struct {
+	Pattern
+	index int
+	re    *regexp.Regexp
+}
+
+type match struct {
+	start      int
+	end        int
+	pattern    *compiledPattern
+	confidence float64
+}
+
+func compilePatternList(patterns []Pattern) []compiledPattern {
+	compiled := make([]compiledPattern, 0, len(patterns))
+	for i, pattern := range patterns {
+		if pattern.Regex == "" {
+			continue
+		}
+		compiled = append(compiled, compiledPattern{
+			Pattern: pattern,
+			index:   i,
+			re:      regexp.MustCompile(pattern.Regex),
+		})
+	}
+	return compiled
+}
+
+func (dm *DefaultMasker) findMatches(st walkState, s string) []match {
+	if dm.overlapMode == OverlapFirstMatch {
+		return dm.findFirstMatches(st, s)
+	}
+
+	var buf [64]int
+	first, ok := dm.candidates(s, buf[:])
+	if !ok {
+		return nil
+	}
+
+	var candidates []match
+	for i := range dm.compiledPatterns {
+		cp := &dm.compiledPatterns[i]
+		if cp.MaskFunc == nil {
+			continue
+		}
+		start := 0
+		if first != nil {
+			if first[i] < 0 {
+				continue
+			}
+			start = scanStart(s, first[i])
+		}
+		if st.budgetExceeded() {
+			return nil
+		}
+		for _, loc := range cp.re.FindAllStringIndex(s[start:], -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			m := match{start: start + loc[0], end: start + loc[1], pattern: cp}
+			if dm.acceptMatch(st, s, &m) {
+				candidates = append(candidates, m)
+			}
+		}
+	}
+	return resolveOverlaps(candidates, dm.overlapMode)
+}
+
+func (dm *DefaultMasker) findFirstMatches(st walkState, s string) []match {
+	var buf [64]int
+	first, ok := dm.candidates(s, buf[:])
+	if !ok {
+		return nil
+	}
+
+	re, start := dm.cache, 0
+	if first != nil {
+		start = len(s)
+		for _, pos := range first {
+			if pos >= 0 {
+				start = min(start, pos)
+			}
+		}
+		start = scanStart(s, start)
+		if subset := dm.literals.subsetRegex(dm.compiledPatterns, first); subset != nil {
+			re = subset
+		}
+	}
+
+	var matches []match
+	for _, loc := range re.FindAllStringIndex(s[start:], -1) {
+		if loc[0] == loc[1] {
+			continue
+		}
+		loc[0], loc[1] = start+loc[0], start+loc[1]
+		candidate := s[loc[0]:loc[1]]
+		rejected := false
+		for i := range dm.compiledPatterns {
+			cp := &dm.compiledPatterns[i]
+			if first != nil && first[i] < 0 {
+				continue
+			}
+			if cp.MaskFunc == nil || !cp.re.MatchString(candidate) {
+				continue
+			}
+
+			// The span belongs to the first pattern that matches it. When that
+			// pattern rejects it, the next ones get their own match inside it.
+			m := match{start: loc[0], end: loc[1], pattern: cp}
+			if rejected {
+				var found bool
+				if m.start, m.end, found = matchWithin(cp, s, loc[0], loc[1]); !found {
+					continue
+				}
+			}
+			if dm.acceptMatch(st, s, &m) {
+				matches = append(matches, m)
+				break
+			}
+			rejected = true
+		}
+	}
+	return matches
+}
+
+// matchWithin returns the leftmost match of cp that lies inside s[start:end].
+// The surrounding runes stay visible so that assertions such as \b behave as
+// in a scan of the whole string.
+func matchWithin(cp *compiledPattern, s string, start, end int) (int, int, bool) {
+	from := scanStart(s, start)
+	for _, loc := range cp.re.FindAllStringIndex(s[from:runeCeil(s, end+1)], -1) {
+		matchStart, matchEnd := from+loc[0], from+loc[1]
+		if matchStart >= end {
+			break
+		}
+		if matchStart >= start && matchEnd <= end && matchStart < matchEnd {
+			return matchStart, matchEnd, true
+		}
+	}
+	return 0, 0, false
+}
+
+func resolveOverlaps(candidates []match, mode OverlapMode) []match {
+	if len(candidates) < 2 {
+		return candidates
+	}
+
+	switch mode {
+	case OverlapPriority:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i], candidates[j]
+			if a.pattern.Priority != b.pattern.Priority {
+				return a.pattern.Priority > b.pattern.Priority
+			}
+			if a.end-a.start != b.end-b.start {
+				return a.end-a.start > b.end-b.start
+			}
+			if a.start != b.start {
+				return a.start < b.start
+			}
+			return a.pattern.index < b.pattern.index
+		})
+	default:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			a, b := candidates[i], candidates[j]
+			if a.start != b.start {
+				return a.start < b.start
+			}
+			if a.end-a.start != b.end-b.start {
+				return a.end-a.start > b.end-b.start
+			}
+			if a.pattern.Priority != b.pattern.Priority {
+				return a.pattern.Priority > b.pattern.Priority
+			}
+			return a.pattern.index < b.pattern.index
+		})
+	}
+
+	var selected []match
+	for _, candidate := range candidates {
+		if !overlapsAny(candidate, selected) {
+			selected = append(selected, candidate)
+		}
+	}
+
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].start < selected[j].start
+	})
+	return selected
+}
+
+func overlapsAny(m match, selected []match) bool {
+	for _, other := range selected {
+		if m.start < other.end && other.start < m.end {
+			return true
+		}
+	}
+	return false
+}
+
+func applyMatches(s string, matches []match) string {
+	if len(matches) == 0 {
+		return s
+	}
+
+	var b strings.Builder
+	b.Grow(len(s))
+	last := 0
+	for _, m := range matches {
+		b.WriteString(s[last:m.start])
+		b.WriteString(m.pattern.MaskFunc(s[m.start:m.end]))
+		last = m.end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
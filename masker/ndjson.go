@@ -0,0 +1,86 @@
+package masker
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+type MalformedLine struct {
+	Line int
+	Err  error
+}
+
+type NDJSONResult struct {
+	Lines     int
+	Malformed []MalformedLine
+}
+
+func MaskNDJSON(r io.Reader, w io.Writer, opts ...Option) (NDJSONResult, error) {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskNDJSON(r, w)
+}
+
+// MaskNDJSON masks newline-delimited JSON line by line. Lines holding a JSON
+// value are masked like MaskJSON; any other non-blank line is masked as text
+// and listed in the result as malformed. Line endings are preserved, so the
+// output always has the same number of lines as the input.
+func (dm *DefaultMasker) MaskNDJSON(r io.Reader, w io.Writer) (NDJSONResult, error) {
+	var result NDJSONResult
+	reader := bufio.NewReader(r)
+	out := bufio.NewWriter(w)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			result.Lines++
+
+			content, ending := splitLineEnding(line)
+			masked, malformed := dm.maskNDJSONLine(content)
+			if malformed != nil {
+				result.Malformed = append(result.Malformed, MalformedLine{Line: result.Lines, Err: malformed})
+			}
+			out.Write(masked)
+			out.Write(ending)
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			out.Flush()
+			return result, err
+		}
+	}
+
+	return result, out.Flush()
+}
+
+func (dm *DefaultMasker) maskNDJSONLine(line []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(line)) == 0 {
+		return line, nil
+	}
+
+	var syntaxErr error
+	if json.Valid(line) {
+		masked, err := dm.MaskJSON(line)
+		if err == nil {
+			return masked, nil
+		}
+		syntaxErr = err
+	} else {
+		var value interface{}
+		syntaxErr = json.Unmarshal(line, &value)
+	}
+	return []byte(dm.maskString(dm.newWalk(nil), string(line))), syntaxErr
+}
+
+func splitLineEnding(line []byte) ([]byte, []byte) {
+	switch {
+	case bytes.HasSuffix(line, []byte("\r\n")):
+		return line[:len(line)-2], line[len(line)-2:]
+	case bytes.HasSuffix(line, []byte("\n")):
+		return line[:len(line)-1], line[len(line)-1:]
+	}
+	return line, nil
+}
@@ -0,0 +1,65 @@
+package masker
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type NumericKind uint
+
+const (
+	NumericInt NumericKind = 1 << iota
+	NumericUint
+	NumericFloat
+	NumericJSONNumber
+
+	NumericAll = NumericInt | NumericUint | NumericFloat | NumericJSONNumber
+)
+
+func numericString(value interface{}) (string, NumericKind, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.FormatInt(int64(v), 10), NumericInt, true
+	case int8:
+		return strconv.FormatInt(int64(v), 10), NumericInt, true
+	case int16:
+		return strconv.FormatInt(int64(v), 10), NumericInt, true
+	case int32:
+		return strconv.FormatInt(int64(v), 10), NumericInt, true
+	case int64:
+		return strconv.FormatInt(v, 10), NumericInt, true
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), NumericUint, true
+	case uint8:
+		return strconv.FormatUint(uint64(v), 10), NumericUint, true
+	case uint16:
+		return strconv.FormatUint(uint64(v), 10), NumericUint, true
+	case uint32:
+		return strconv.FormatUint(uint64(v), 10), NumericUint, true
+	case uint64:
+		return strconv.FormatUint(v, 10), NumericUint, true
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), NumericFloat, true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), NumericFloat, true
+	case json.Number:
+		return v.String(), NumericJSONNumber, true
+	default:
+		return "", 0, false
+	}
+}
+
+func (dm *DefaultMasker) processNumber(st walkState, value interface{}, s string, kind NumericKind) interface{} {
+	if dm.numericKinds&kind == 0 {
+		return value
+	}
+
+	masked := dm.maskString(st, s)
+	if masked == s {
+		return value
+	}
+	if dm.numericSentinel != nil {
+		return dm.numericSentinel
+	}
+	return masked
+}
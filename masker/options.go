@@ -1,13 +1,63 @@
 package masker
 
+import "time"
+
 type Pattern struct {
 	Name     string
 	Regex    string
 	MaskFunc func(string) string
+	Priority int
+	Validate func(string) bool
+	Severity Severity
+
+	// Keywords raise the confidence of a match when one of them appears
+	// within KeywordWindow characters of it or in the enclosing map key.
+	Keywords       []string
+	KeywordWindow  int
+	RequireKeyword bool
+	Confidence     float64
+	KeywordBoost   float64
 }
 
+// OverlapMode controls which detection wins when two patterns match
+// overlapping regions of the same string.
+type OverlapMode int
+
+const (
+	// OverlapFirstMatch keeps the historical behaviour: the combined regex
+	// picks the first alternative that matches at the leftmost position.
+	OverlapFirstMatch OverlapMode = iota
+	// OverlapLeftmostLongest prefers the match that starts first, then the
+	// longest one, then the highest Priority.
+	OverlapLeftmostLongest
+	// OverlapPriority prefers the highest Priority, then the longest match,
+	// then the one that starts first.
+	OverlapPriority
+)
+
 type Config struct {
-	Patterns []Pattern
+	Patterns      []Pattern
+	OverlapMode   OverlapMode
+	MinConfidence float64
+
+	SensitiveKeys     []string
+	KeyMaskFunc       func(string) string
+	KeyValueDetection bool
+
+	NumericKinds    NumericKind
+	NumericSentinel interface{}
+
+	MaskStringers bool
+
+	MaxDepth    int
+	MaxElements int
+	LimitPolicy LimitPolicy
+
+	MaxStringLength int
+	TimeBudget      time.Duration
+	OnWarning       func(pattern, message string)
+
+	StrictMode bool
 }
 
 type Option func(*Config)
@@ -28,6 +78,118 @@ func WithCustomPattern(name, regex string, maskFunc func(string) string) Option
 	}
 }
 
+func WithPriorityPattern(name, regex string, priority int, maskFunc func(string) string) Option {
+	return func(c *Config) {
+		c.Patterns = append(c.Patterns, Pattern{
+			Name:     name,
+			Regex:    regex,
+			MaskFunc: maskFunc,
+			Priority: priority,
+		})
+	}
+}
+
+func WithValidatedPattern(name, regex string, validate func(string) bool, maskFunc func(string) string) Option {
+	return func(c *Config) {
+		c.Patterns = append(c.Patterns, Pattern{
+			Name:     name,
+			Regex:    regex,
+			MaskFunc: maskFunc,
+			Validate: validate,
+		})
+	}
+}
+
+func WithOverlapMode(mode OverlapMode) Option {
+	return func(c *Config) {
+		c.OverlapMode = mode
+	}
+}
+
+func WithMinConfidence(threshold float64) Option {
+	return func(c *Config) {
+		c.MinConfidence = threshold
+	}
+}
+
+func WithSensitiveKeys(keys ...string) Option {
+	return func(c *Config) {
+		c.SensitiveKeys = append(c.SensitiveKeys, keys...)
+	}
+}
+
+func WithKeyMaskFunc(maskFunc func(string) string) Option {
+	return func(c *Config) {
+		c.KeyMaskFunc = maskFunc
+	}
+}
+
+func WithKeyValueDetection() Option {
+	return func(c *Config) {
+		c.KeyValueDetection = true
+	}
+}
+
+func WithNumericMasking(kinds NumericKind) Option {
+	return func(c *Config) {
+		c.NumericKinds = kinds
+	}
+}
+
+func WithNumericSentinel(sentinel interface{}) Option {
+	return func(c *Config) {
+		c.NumericSentinel = sentinel
+	}
+}
+
+func WithStringerMasking() Option {
+	return func(c *Config) {
+		c.MaskStringers = true
+	}
+}
+
+func WithMaxDepth(depth int) Option {
+	return func(c *Config) {
+		c.MaxDepth = depth
+	}
+}
+
+func WithMaxElements(elements int) Option {
+	return func(c *Config) {
+		c.MaxElements = elements
+	}
+}
+
+func WithLimitPolicy(policy LimitPolicy) Option {
+	return func(c *Config) {
+		c.LimitPolicy = policy
+	}
+}
+
+func WithMaxStringLength(length int) Option {
+	return func(c *Config) {
+		c.MaxStringLength = length
+	}
+}
+
+func WithTimeBudget(budget time.Duration) Option {
+	return func(c *Config) {
+		c.TimeBudget = budget
+	}
+}
+
+func WithWarningHandler(handler func(pattern, message string)) Option {
+	return func(c *Config) {
+		c.OnWarning = handler
+	}
+}
+
+func WithStrictMode() Option {
+	return func(c *Config) {
+		c.StrictMode = true
+	}
+}
+
 func DefaultConfig() Config {
 	return Config{
 		Patterns: DefaultPatterns(),
@@ -7,14 +7,19 @@ import (
 func DefaultPatterns() []Pattern {
 	return []Pattern{
 		{
-			Name:  "credit_card",
-			Regex: `\b(?:\d[ -]*?){13,16}\d\b`,
+			Name:     "credit_card",
+			Regex:    `\b(?:\d[ -]*?){13,16}\d\b`,
+			Severity: SeverityHigh,
+			Validate: func(card string) bool {
+				cleanCard := CleanNumber(card)
+				return len(cleanCard) == 16 && IsAllDigits(cleanCard)
+			},
 			MaskFunc: func(card string) string {
 				cleanCard := CleanNumber(card)
-				if len(cleanCard) == 16 && IsAllDigits(cleanCard) {
-					return cleanCard[:4] + "********" + cleanCard[12:]
+				if len(cleanCard) != 16 {
+					return card
 				}
-				return card
+				return cleanCard[:4] + "********" + cleanCard[12:]
 			},
 		},
 	}
@@ -38,3 +43,9 @@ func IsAllDigits(s string) bool {
 	}
 	return true
 }
+
+const RedactedValue = "[REDACTED]"
+
+func Redact(string) string {
+	return RedactedValue
+}
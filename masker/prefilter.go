@@ -0,0 +1,160 @@
+package masker
+
+import (
+	"regexp/syntax"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	surrogateMin = 0xD800
+	surrogateMax = 0xDFFF
+)
+
+// prefilter holds every byte a match of any pattern can start with. Inputs
+// that contain none of them cannot match and skip the regex engine.
+type prefilter struct {
+	any   bool
+	first [256]bool
+}
+
+func newPrefilter(patterns []compiledPattern) *prefilter {
+	p := &prefilter{}
+	for _, cp := range patterns {
+		re, err := syntax.Parse(cp.Regex, syntax.Perl)
+		if err != nil {
+			p.any = true
+			return p
+		}
+		if nullable := p.addFirstBytes(re.Simplify()); nullable {
+			p.any = true
+			return p
+		}
+	}
+	return p
+}
+
+func (p *prefilter) mayMatch(b []byte) bool {
+	if p.any {
+		return true
+	}
+	for _, c := range b {
+		if p.first[c] {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *prefilter) mayMatchString(s string) bool {
+	if p.any {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		if p.first[s[i]] {
+			return true
+		}
+	}
+	return false
+}
+
+// addFirstBytes adds the bytes re can start with and reports whether re can
+// match the empty string, in which case the caller must also look at what
+// follows it.
+func (p *prefilter) addFirstBytes(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpNoMatch:
+		return false
+	case syntax.OpLiteral:
+		if len(re.Rune) == 0 {
+			return true
+		}
+		r := re.Rune[0]
+		p.addRuneRange(r, r)
+		if re.Flags&syntax.FoldCase != 0 {
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				p.addRuneRange(f, f)
+			}
+		}
+		return false
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			p.addRuneRange(re.Rune[i], re.Rune[i+1])
+		}
+		return false
+	case syntax.OpAnyCharNotNL:
+		for c := range p.first {
+			if c != '\n' {
+				p.first[c] = true
+			}
+		}
+		return false
+	case syntax.OpAnyChar:
+		for c := range p.first {
+			p.first[c] = true
+		}
+		return false
+	case syntax.OpCapture, syntax.OpPlus:
+		return p.addFirstBytes(re.Sub[0])
+	case syntax.OpStar, syntax.OpQuest:
+		p.addFirstBytes(re.Sub[0])
+		return true
+	case syntax.OpRepeat:
+		nullable := p.addFirstBytes(re.Sub[0])
+		return nullable || re.Min == 0
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if !p.addFirstBytes(sub) {
+				return false
+			}
+		}
+		return true
+	case syntax.OpAlternate:
+		nullable := false
+		for _, sub := range re.Sub {
+			if p.addFirstBytes(sub) {
+				nullable = true
+			}
+		}
+		return nullable
+	default:
+		// Empty-width assertions such as \b, ^ and $.
+		return true
+	}
+}
+
+func (p *prefilter) addRuneRange(lo, hi rune) {
+	for c := lo; c <= hi && c < utf8.RuneSelf; c++ {
+		p.first[c] = true
+	}
+	if hi < utf8.RuneSelf {
+		return
+	}
+	if lo < utf8.RuneSelf {
+		lo = utf8.RuneSelf
+	}
+	if lo >= surrogateMin && lo <= surrogateMax {
+		lo = surrogateMax + 1
+	}
+	if hi >= surrogateMin && hi <= surrogateMax {
+		hi = surrogateMin - 1
+	}
+	if lo > hi {
+		return
+	}
+	// The regex engine decodes invalid UTF-8 as utf8.RuneError, so a class
+	// containing it can start on any non-ASCII byte.
+	if lo <= utf8.RuneError && utf8.RuneError <= hi {
+		for c := utf8.RuneSelf; c < len(p.first); c++ {
+			p.first[c] = true
+		}
+		return
+	}
+	var buf [utf8.UTFMax]byte
+	utf8.EncodeRune(buf[:], lo)
+	from := buf[0]
+	utf8.EncodeRune(buf[:], hi)
+	for c := int(from); c <= int(buf[0]); c++ {
+		p.first[c] = true
+	}
+}
@@ -0,0 +1,182 @@
+package masker
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const DefaultReloadInterval = 5 * time.Second
+
+type WatchOptions struct {
+	// Interval between two checks of the config file. Defaults to
+	// DefaultReloadInterval.
+	Interval time.Duration
+	// OnError is called when a changed file cannot be loaded. The previous
+	// rules stay active.
+	OnError func(error)
+	// OnReload is called after new rules have been swapped in.
+	OnReload func(Config)
+}
+
+// ReloadableMasker delegates to a DefaultMasker that can be replaced at any
+// time. Calls in flight keep using the masker they started with.
+type ReloadableMasker struct {
+	current atomic.Pointer[DefaultMasker]
+
+	path    string
+	opts    WatchOptions
+	modTime time.Time
+	size    int64
+
+	mu        sync.Mutex
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewReloadable(config Config) (*ReloadableMasker, error) {
+	rm := &ReloadableMasker{}
+	if err := rm.Update(config); err != nil {
+		return nil, err
+	}
+	return rm, nil
+}
+
+// WatchConfig loads the config file at path and polls it for changes until
+// Close is called. The initial load must succeed.
+func WatchConfig(path string, opts WatchOptions) (*ReloadableMasker, error) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultReloadInterval
+	}
+
+	rm := &ReloadableMasker{path: path, opts: opts}
+	if err := rm.Reload(); err != nil {
+		return nil, err
+	}
+
+	rm.stop = make(chan struct{})
+	rm.done = make(chan struct{})
+	go rm.watch()
+
+	return rm, nil
+}
+
+// Update validates config and swaps it in. On error the current rules are
+// kept.
+func (rm *ReloadableMasker) Update(config Config) error {
+	if err := validateConfig(config); err != nil {
+		return err
+	}
+	rm.current.Store(newFromConfig(config))
+	return nil
+}
+
+// Reload re-reads the watched config file, even if it did not change.
+func (rm *ReloadableMasker) Reload() error {
+	if rm.path == "" {
+		return errors.New("masker: no config file to reload")
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return rm.reload()
+}
+
+func (rm *ReloadableMasker) reload() error {
+	info, err := os.Stat(rm.path)
+	if err != nil {
+		return err
+	}
+	rm.modTime, rm.size = info.ModTime(), info.Size()
+
+	config, err := LoadConfig(rm.path)
+	if err != nil {
+		return err
+	}
+	if err := rm.Update(config); err != nil {
+		return fmt.Errorf("%s: %w", rm.path, err)
+	}
+	if rm.opts.OnReload != nil {
+		rm.opts.OnReload(config)
+	}
+	return nil
+}
+
+func (rm *ReloadableMasker) watch() {
+	defer close(rm.done)
+
+	ticker := time.NewTicker(rm.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-rm.stop:
+			return
+		case <-ticker.C:
+			rm.poll()
+		}
+	}
+}
+
+func (rm *ReloadableMasker) poll() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	info, err := os.Stat(rm.path)
+	switch {
+	case err != nil:
+		if rm.size < 0 {
+			return
+		}
+		rm.modTime, rm.size = time.Time{}, -1
+	case info.ModTime().Equal(rm.modTime) && info.Size() == rm.size:
+		return
+	default:
+		err = rm.reload()
+	}
+	if err != nil && rm.opts.OnError != nil {
+		rm.opts.OnError(err)
+	}
+}
+
+// Close stops watching the config file. The last loaded rules stay usable.
+func (rm *ReloadableMasker) Close() {
+	if rm.stop == nil {
+		return
+	}
+	rm.closeOnce.Do(func() {
+		close(rm.stop)
+	})
+	<-rm.done
+}
+
+func (rm *ReloadableMasker) Current() *DefaultMasker {
+	return rm.current.Load()
+}
+
+func (rm *ReloadableMasker) Mask(data interface{}) interface{} {
+	return rm.Current().Mask(data)
+}
+
+func (rm *ReloadableMasker) MaskInterface(data interface{}) interface{} {
+	return rm.Current().MaskInterface(data)
+}
+
+func (rm *ReloadableMasker) MaskWithReport(data interface{}) (interface{}, *Report) {
+	return rm.Current().MaskWithReport(data)
+}
+
+func validateConfig(config Config) error {
+	var errs []error
+	for i, pattern := range config.Patterns {
+		if _, err := regexp.Compile(pattern.Regex); err != nil {
+			errs = append(errs, &ConfigError{Field: fmt.Sprintf("patterns[%d] (%s).regex", i, pattern.Name), Err: err})
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,108 @@
+package masker
+
+import "fmt"
+
+type Finding struct {
+	Pattern    string
+	Path       string
+	Start      int
+	End        int
+	Rejected   bool
+	Reason     string
+	Confidence float64
+	Severity   Severity
+}
+
+type Report struct {
+	Findings []Finding
+}
+
+func (r *Report) Masked() []Finding {
+	var masked []Finding
+	for _, f := range r.Findings {
+		if !f.Rejected {
+			masked = append(masked, f)
+		}
+	}
+	return masked
+}
+
+func (r *Report) Rejected() []Finding {
+	var rejected []Finding
+	for _, f := range r.Findings {
+		if f.Rejected {
+			rejected = append(rejected, f)
+		}
+	}
+	return rejected
+}
+
+type walkState struct {
+	report    *Report
+	path      string
+	key       string
+	sensitive bool
+	depth     int
+	shared    *walkShared
+}
+
+func (st walkState) childKey(key interface{}) walkState {
+	st.key, _ = key.(string)
+	if st.report == nil {
+		return st
+	}
+	if st.path == "" {
+		st.path = fmt.Sprint(key)
+	} else {
+		st.path = st.path + "." + fmt.Sprint(key)
+	}
+	return st
+}
+
+func (st walkState) childIndex(i int) walkState {
+	if st.report == nil {
+		return st
+	}
+	st.path = fmt.Sprintf("%s[%d]", st.path, i)
+	return st
+}
+
+func (st walkState) record(m match, rejected bool, reason string) {
+	if st.report == nil {
+		return
+	}
+	st.report.Findings = append(st.report.Findings, Finding{
+		Pattern:    m.pattern.Name,
+		Path:       st.path,
+		Start:      m.start,
+		End:        m.end,
+		Rejected:   rejected,
+		Reason:     reason,
+		Confidence: m.confidence,
+		Severity:   m.pattern.severity(),
+	})
+}
+
+func (st walkState) recordKey() {
+	if st.report == nil {
+		return
+	}
+	st.report.Findings = append(st.report.Findings, Finding{
+		Pattern:    SensitiveKeyPattern,
+		Path:       st.path,
+		Confidence: 1,
+		Severity:   SeverityHigh,
+	})
+}
+
+func (st walkState) recordLimit(reason string) {
+	if st.report == nil {
+		return
+	}
+	st.report.Findings = append(st.report.Findings, Finding{
+		Pattern:    LimitPattern,
+		Path:       st.path,
+		Reason:     reason,
+		Confidence: 1,
+	})
+}
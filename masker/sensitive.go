@@ -0,0 +1,57 @@
+package masker
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+)
+
+// Sensitive wraps a value so that printing, logging or marshaling it never
+// exposes the raw content. Use Reveal to get the value back.
+type Sensitive[T any] struct {
+	value T
+}
+
+func NewSensitive[T any](value T) Sensitive[T] {
+	return Sensitive[T]{value: value}
+}
+
+func (s Sensitive[T]) Reveal() T {
+	return s.value
+}
+
+func (s Sensitive[T]) String() string {
+	return RedactedValue
+}
+
+func (s Sensitive[T]) GoString() string {
+	return fmt.Sprintf("masker.Sensitive[%T]{%s}", s.value, RedactedValue)
+}
+
+func (s Sensitive[T]) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, s.GoString())
+	case verb == 'q':
+		fmt.Fprint(f, strconv.Quote(RedactedValue))
+	default:
+		fmt.Fprint(f, RedactedValue)
+	}
+}
+
+func (s Sensitive[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(RedactedValue)
+}
+
+func (s Sensitive[T]) MarshalText() ([]byte, error) {
+	return []byte(RedactedValue), nil
+}
+
+func (s Sensitive[T]) LogValue() slog.Value {
+	return slog.StringValue(RedactedValue)
+}
+
+func (s *Sensitive[T]) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &s.value)
+}
@@ -0,0 +1,36 @@
+package masker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"unicode/utf8"
+)
+
+func Keep(s string) string {
+	return s
+}
+
+func Replace(replacement string) func(string) string {
+	return func(string) string {
+		return replacement
+	}
+}
+
+func HashSHA256(salt string) func(string) string {
+	return func(s string) string {
+		sum := sha256.Sum256([]byte(salt + s))
+		return hex.EncodeToString(sum[:])
+	}
+}
+
+func PartialMask(first, last int) func(string) string {
+	return func(s string) string {
+		n := utf8.RuneCountInString(s)
+		if first+last >= n {
+			return strings.Repeat("*", n)
+		}
+		runes := []rune(s)
+		return string(runes[:first]) + strings.Repeat("*", n-first-last) + string(runes[n-last:])
+	}
+}
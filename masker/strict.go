@@ -0,0 +1,50 @@
+package masker
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+const UnsupportedPattern = "unsupported"
+
+func (dm *DefaultMasker) processStrict(st walkState, value interface{}) interface{} {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return value
+	case reflect.String:
+		s := rv.String()
+		if masked := dm.maskString(st, s); masked != s {
+			return masked
+		}
+		return value
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array, reflect.Ptr, reflect.Interface:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return dm.unsupported(st, fmt.Sprintf("marshal error: %v", err))
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return dm.unsupported(st, fmt.Sprintf("unmarshal error: %v", err))
+		}
+		return dm.processValue(st, decoded)
+	default:
+		return dm.unsupported(st, fmt.Sprintf("unsupported type %T", value))
+	}
+}
+
+func (dm *DefaultMasker) unsupported(st walkState, reason string) string {
+	if st.report != nil {
+		st.report.Findings = append(st.report.Findings, Finding{
+			Pattern:    UnsupportedPattern,
+			Path:       st.path,
+			Reason:     reason,
+			Confidence: 1,
+		})
+	}
+	return RedactedValue
+}
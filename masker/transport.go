@@ -0,0 +1,239 @@
+package masker
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DefaultMaxCaptureSize = 64 << 10
+
+type Exchange struct {
+	Method   string
+	URL      string
+	Request  string
+	Response string
+	Status   int
+	Err      error
+	Duration time.Duration
+}
+
+// Transport is an http.RoundTripper that records masked dumps of every
+// request and response going through Base. The bytes sent and received are
+// never modified; only the first MaxCaptureSize bytes of each body are
+// captured for the dump. Response bodies are captured as the caller reads
+// them, so the exchange is reported once the body hits EOF or is closed.
+type Transport struct {
+	Base           http.RoundTripper
+	Masker         *DefaultMasker
+	OnExchange     func(Exchange)
+	Logger         *slog.Logger
+	MaxCaptureSize int
+}
+
+func NewTransport(base http.RoundTripper, opts ...Option) *Transport {
+	return &Transport{
+		Base:   base,
+		Masker: NewWithOpts(opts...).(*DefaultMasker),
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	dm := t.Masker
+	if dm == nil {
+		dm = defaultMasker()
+	}
+	limit := t.MaxCaptureSize
+	if limit <= 0 {
+		limit = DefaultMaxCaptureSize
+	}
+
+	outgoing := req
+	var requestBody []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		captured, body, err := captureBody(req.Body, limit)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = captured
+		outgoing = req.Clone(req.Context())
+		outgoing.Body = body
+	}
+
+	exchange := Exchange{
+		Method:  req.Method,
+		URL:     dm.maskRequestURL(req),
+		Request: dm.dumpRequest(req, requestBody),
+	}
+
+	start := time.Now()
+	resp, err := base.RoundTrip(outgoing)
+	exchange.Duration = time.Since(start)
+
+	if err != nil {
+		exchange.Err = dm.maskError(dm.newWalk(nil), err)
+		t.emit(req.Context(), exchange)
+		return resp, err
+	}
+
+	exchange.Status = resp.StatusCode
+	if resp.Body == nil || resp.Body == http.NoBody {
+		exchange.Response = dm.dumpResponse(resp, nil)
+		t.emit(req.Context(), exchange)
+		return resp, nil
+	}
+
+	ctx := req.Context()
+	resp.Body = &teeBody{
+		body:  resp.Body,
+		limit: limit,
+		done: func(captured []byte) {
+			exchange.Response = dm.dumpResponse(resp, captured)
+			t.emit(ctx, exchange)
+		},
+	}
+	return resp, nil
+}
+
+func (t *Transport) emit(ctx context.Context, exchange Exchange) {
+	if t.OnExchange != nil {
+		t.OnExchange(exchange)
+	}
+	if t.Logger != nil {
+		attrs := []slog.Attr{
+			slog.String("method", exchange.Method),
+			slog.String("url", exchange.URL),
+			slog.Duration("duration", exchange.Duration),
+			slog.String("request", exchange.Request),
+		}
+		if exchange.Err != nil {
+			attrs = append(attrs, slog.String("error", exchange.Err.Error()))
+		} else {
+			attrs = append(attrs, slog.Int("status", exchange.Status), slog.String("response", exchange.Response))
+		}
+		t.Logger.LogAttrs(ctx, slog.LevelInfo, "http exchange", attrs...)
+	}
+}
+
+// teeBody keeps a copy of the first limit bytes read through it and calls
+// done once, on EOF or Close, whichever comes first.
+type teeBody struct {
+	body     io.ReadCloser
+	limit    int
+	captured bytes.Buffer
+	once     sync.Once
+	done     func([]byte)
+}
+
+func (b *teeBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if room := b.limit - b.captured.Len(); room > 0 {
+		b.captured.Write(p[:min(n, room)])
+	}
+	if errors.Is(err, io.EOF) {
+		b.finish()
+	}
+	return n, err
+}
+
+func (b *teeBody) Close() error {
+	err := b.body.Close()
+	b.finish()
+	return err
+}
+
+func (b *teeBody) finish() {
+	b.once.Do(func() {
+		b.done(b.captured.Bytes())
+	})
+}
+
+type capturedBody struct {
+	io.Reader
+	io.Closer
+}
+
+func captureBody(body io.ReadCloser, limit int) ([]byte, io.ReadCloser, error) {
+	captured, err := io.ReadAll(io.LimitReader(body, int64(limit)))
+	if err != nil {
+		body.Close()
+		return nil, nil, err
+	}
+	return captured, capturedBody{
+		Reader: io.MultiReader(bytes.NewReader(captured), body),
+		Closer: body,
+	}, nil
+}
+
+func (dm *DefaultMasker) maskRequestURL(req *http.Request) string {
+	if req.URL == nil {
+		return ""
+	}
+	return dm.maskURL(req.URL.String())
+}
+
+func (dm *DefaultMasker) maskURL(raw string) string {
+	raw = MaskURL(raw)
+	base, query, found := strings.Cut(raw, "?")
+	if !found {
+		return dm.maskString(dm.newWalk(nil), raw)
+	}
+	return dm.maskString(dm.newWalk(nil), base) + "?" + dm.MaskQuery(query)
+}
+
+func (dm *DefaultMasker) dumpRequest(req *http.Request, body []byte) string {
+	var b strings.Builder
+	uri := req.RequestURI
+	if uri == "" && req.URL != nil {
+		uri = req.URL.RequestURI()
+	}
+	fmt.Fprintf(&b, "%s %s HTTP/%d.%d\r\n", req.Method, dm.maskURL(uri), req.ProtoMajor, req.ProtoMinor)
+
+	host := req.Host
+	if host == "" && req.URL != nil {
+		host = req.URL.Host
+	}
+	fmt.Fprintf(&b, "Host: %s\r\n", host)
+	dm.MaskHeader(req.Header).Write(&b)
+	b.WriteString("\r\n")
+	b.WriteString(dm.maskBody(req.Header.Get("Content-Type"), body))
+	return b.String()
+}
+
+func (dm *DefaultMasker) dumpResponse(resp *http.Response, body []byte) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "HTTP/%d.%d %s\r\n", resp.ProtoMajor, resp.ProtoMinor, resp.Status)
+	dm.MaskHeader(resp.Header).Write(&b)
+	b.WriteString("\r\n")
+	b.WriteString(dm.maskBody(resp.Header.Get("Content-Type"), body))
+	return b.String()
+}
+
+func (dm *DefaultMasker) maskBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if masked, err := dm.MaskJSON(body); err == nil {
+			return string(masked)
+		}
+	case mediaType == "application/x-www-form-urlencoded":
+		return dm.MaskQuery(string(body))
+	}
+	return dm.maskString(dm.newWalk(nil), string(body))
+}
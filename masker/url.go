@@ -0,0 +1,205 @@
+package masker
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	URLRedacted        = "xxxxx"
+	DefaultURLPriority = 50
+)
+
+const urlRegex = `\b[A-Za-z][A-Za-z0-9+.\-]*://[^\s"'<>]*[^\s"'<>.,;:!?)\]}]` +
+	`|[^\s:@/"'=]+:[^\s@/"']+@(?:tcp|udp|unix)\([^)\s]*\)[^\s"'<>]*[^\s"'<>.,;:!?)\]}]`
+
+func DefaultSensitiveQueryParams() []string {
+	return []string{
+		"token", "access_token", "refresh_token", "id_token",
+		"api_key", "apikey", "key", "secret", "client_secret",
+		"password", "passwd", "pwd", "pass",
+		"signature", "sig", "x-amz-signature", "auth", "authorization",
+	}
+}
+
+func URLPattern(params ...string) Pattern {
+	if len(params) == 0 {
+		params = DefaultSensitiveQueryParams()
+	}
+	return Pattern{
+		Name:     "url_credentials",
+		Regex:    urlRegex,
+		Priority: DefaultURLPriority,
+		Severity: SeverityHigh,
+		Validate: func(s string) bool {
+			return MaskURL(s, params...) != s
+		},
+		MaskFunc: func(s string) string {
+			return MaskURL(s, params...)
+		},
+	}
+}
+
+func WithURLCredentials(params ...string) Option {
+	return func(c *Config) {
+		c.Patterns = append(c.Patterns, URLPattern(params...))
+	}
+}
+
+// MaskURL replaces the userinfo password and the values of sensitive query
+// or fragment parameters in raw, leaving every other byte untouched.
+func MaskURL(raw string, params ...string) string {
+	if len(params) == 0 {
+		params = DefaultSensitiveQueryParams()
+	}
+
+	rest := raw
+	var b strings.Builder
+	b.Grow(len(raw))
+
+	at := -1
+	if i := strings.Index(rest, "://"); i >= 0 {
+		scheme := rest[:i]
+		b.WriteString(rest[:i+3])
+		rest = rest[i+3:]
+		at = userinfoEnd(scheme, rest)
+	} else if end := strings.IndexByte(rest, '('); end >= 0 {
+		at = strings.LastIndex(rest[:end], "@")
+	}
+
+	if at >= 0 {
+		userinfo := rest[:at]
+		if colon := strings.Index(userinfo, ":"); colon >= 0 {
+			b.WriteString(userinfo[:colon+1])
+			b.WriteString(URLRedacted)
+		} else {
+			b.WriteString(userinfo)
+		}
+		rest = rest[at:]
+	}
+
+	query := strings.IndexByte(rest, '?')
+	fragment := strings.IndexByte(rest, '#')
+	switch {
+	case query >= 0 && (fragment < 0 || query < fragment):
+		b.WriteString(rest[:query+1])
+		rest = rest[query+1:]
+		if fragment >= 0 {
+			fragment -= query + 1
+			b.WriteString(maskQueryParams(rest[:fragment], params))
+			b.WriteByte('#')
+			b.WriteString(maskQueryParams(rest[fragment+1:], params))
+		} else {
+			b.WriteString(maskQueryParams(rest, params))
+		}
+	case fragment >= 0:
+		b.WriteString(rest[:fragment+1])
+		b.WriteString(maskQueryParams(rest[fragment+1:], params))
+	default:
+		b.WriteString(rest)
+	}
+
+	return b.String()
+}
+
+// credentialSchemes lists the URL schemes whose userinfo usually carries a
+// password that is not percent-encoded.
+var credentialSchemes = map[string]bool{
+	"postgres": true, "postgresql": true, "mysql": true, "mariadb": true, "sqlserver": true,
+	"mongodb": true, "mongodb+srv": true, "redis": true, "rediss": true,
+	"amqp": true, "amqps": true, "nats": true, "ftp": true, "ftps": true, "sftp": true,
+	"smtp": true, "smtps": true, "ldap": true, "ldaps": true, "clickhouse": true,
+}
+
+// userinfoEnd returns the offset of the '@' ending the userinfo of an
+// authority, or -1. For credential schemes the password may contain '@',
+// '/', '?' or '#', so the userinfo ends at the last '@' followed by a host
+// and optional port; the user name itself must not contain them. For other
+// schemes it ends at the last '@' before the first '/', '?' or '#'.
+func userinfoEnd(scheme, rest string) int {
+	authority := rest
+	if end := strings.IndexAny(rest, "/?#"); end >= 0 {
+		authority = rest[:end]
+	}
+	if !credentialSchemes[strings.ToLower(scheme)] {
+		return strings.LastIndex(authority, "@")
+	}
+	if !strings.Contains(authority, "@") {
+		if _, port, found := strings.Cut(authority, ":"); found && isPort(port) {
+			return -1
+		}
+	}
+
+	at := -1
+	for i, c := range []byte(rest) {
+		if c == '@' && isHostPort(rest[i+1:]) {
+			at = i
+		}
+	}
+	if at < 0 {
+		return -1
+	}
+	if user, _, _ := strings.Cut(rest[:at], ":"); strings.ContainsAny(user, "/?#") {
+		return -1
+	}
+	return at
+}
+
+// isHostPort reports whether s starts with a host list such as
+// "db:5432" or "h1:27017,h2:27017" followed by the end, '/', '?' or '#'.
+func isHostPort(s string) bool {
+	if end := strings.IndexAny(s, "/?#"); end >= 0 {
+		s = s[:end]
+	}
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			c == '.' || c == '-' || c == '_' || c == ':' || c == ',' || c == '[' || c == ']') {
+			return false
+		}
+	}
+	return true
+}
+
+func isPort(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func maskQueryParams(query string, params []string) string {
+	if !strings.Contains(query, "=") {
+		return query
+	}
+
+	pairs := strings.Split(query, "&")
+	for i, pair := range pairs {
+		eq := strings.IndexByte(pair, '=')
+		if eq < 0 || eq == len(pair)-1 {
+			continue
+		}
+		key, err := url.QueryUnescape(pair[:eq])
+		if err != nil {
+			key = pair[:eq]
+		}
+		if isSensitiveParam(key, params) {
+			pairs[i] = pair[:eq+1] + URLRedacted
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func isSensitiveParam(key string, params []string) bool {
+	for _, param := range params {
+		if strings.EqualFold(key, param) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+package masker
+
+import (
+	"strings"
+	"time"
+)
+
+func IsLuhnValid(s string) bool {
+	digits := CleanNumber(s)
+	if len(digits) < 2 || !IsAllDigits(digits) {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+func IsCPFValid(s string) bool {
+	digits := CleanNumber(s)
+	if len(digits) != 11 || !IsAllDigits(digits) || allSameDigit(digits) {
+		return false
+	}
+	return mod11CheckDigit(digits[:9], descendingWeights(10, 9)) == digits[9] &&
+		mod11CheckDigit(digits[:10], descendingWeights(11, 10)) == digits[10]
+}
+
+func IsCNPJValid(s string) bool {
+	digits := CleanNumber(s)
+	if len(digits) != 14 || !IsAllDigits(digits) || allSameDigit(digits) {
+		return false
+	}
+	first := []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	second := append([]int{6}, first...)
+	return mod11CheckDigit(digits[:12], first) == digits[12] &&
+		mod11CheckDigit(digits[:13], second) == digits[13]
+}
+
+func IsIBANValid(s string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(iban) < 15 || len(iban) > 34 {
+		return false
+	}
+
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+func DateInRange(min, max time.Time, layouts ...string) func(string) bool {
+	if len(layouts) == 0 {
+		layouts = []string{"2006-01-02", "02/01/2006", "01/2006", "01/06"}
+	}
+	return func(s string) bool {
+		for _, layout := range layouts {
+			date, err := time.Parse(layout, s)
+			if err != nil {
+				continue
+			}
+			return !date.Before(min) && !date.After(max)
+		}
+		return false
+	}
+}
+
+func mod11CheckDigit(digits string, weights []int) byte {
+	sum := 0
+	for i := range digits {
+		sum += int(digits[i]-'0') * weights[i]
+	}
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+func descendingWeights(from, n int) []int {
+	weights := make([]int, n)
+	for i := range weights {
+		weights[i] = from - i
+	}
+	return weights
+}
+
+func allSameDigit(s string) bool {
+	return strings.Count(s, s[:1]) == len(s)
+}
@@ -0,0 +1,51 @@
+package masker
+
+import "fmt"
+
+type maskedError struct {
+	err error
+	msg string
+}
+
+func (e *maskedError) Error() string {
+	return e.msg
+}
+
+func (e *maskedError) Unwrap() error {
+	return e.err
+}
+
+func (dm *DefaultMasker) maskBytes(st walkState, b []byte) []byte {
+	if !dm.compiled {
+		return b
+	}
+	if dm.maxStringLength <= 0 || len(b) <= dm.maxStringLength {
+		if !dm.prefilter.mayMatch(b) || !dm.cache.Match(b) {
+			return b
+		}
+	}
+	s := string(b)
+	masked := dm.maskString(st, s)
+	if masked == s {
+		return b
+	}
+	return []byte(masked)
+}
+
+func (dm *DefaultMasker) maskError(st walkState, err error) error {
+	msg := err.Error()
+	masked := dm.maskString(st, msg)
+	if masked == msg {
+		return err
+	}
+	return &maskedError{err: err, msg: masked}
+}
+
+func (dm *DefaultMasker) maskStringer(st walkState, value fmt.Stringer) interface{} {
+	s := value.String()
+	masked := dm.maskString(st, s)
+	if masked == s {
+		return value
+	}
+	return masked
+}
@@ -0,0 +1,108 @@
+package masker
+
+import (
+	"bufio"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	xmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	xmlAttrEscaper = strings.NewReplacer(
+		"&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;",
+		"\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;",
+	)
+)
+
+func MaskXML(r io.Reader, w io.Writer, opts ...Option) error {
+	return NewWithOpts(opts...).(*DefaultMasker).MaskXML(r, w)
+}
+
+// MaskXML streams an XML document from r to w, masking character data,
+// comments and attribute values. Elements and attributes whose local name
+// matches the sensitive key rules have their whole content masked with the
+// key mask function. Namespace prefixes are written exactly as read.
+func (dm *DefaultMasker) MaskXML(r io.Reader, w io.Writer) error {
+	st := dm.newWalk(nil)
+	keys := dm.keyRules()
+	decoder := xml.NewDecoder(r)
+	out := bufio.NewWriter(w)
+
+	var sensitive []bool
+	inSensitive := func() bool {
+		return len(sensitive) > 0 && sensitive[len(sensitive)-1]
+	}
+
+	for {
+		token, err := decoder.RawToken()
+		if errors.Is(err, io.EOF) {
+			if len(sensitive) > 0 {
+				line, _ := decoder.InputPos()
+				out.Flush()
+				return &xml.SyntaxError{Msg: "unexpected EOF", Line: line}
+			}
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			elementSensitive := inSensitive() || IsSensitiveKey(t.Name.Local, keys)
+			sensitive = append(sensitive, elementSensitive)
+
+			out.WriteString("<" + xmlName(t.Name))
+			for _, attr := range t.Attr {
+				value := attr.Value
+				switch {
+				case attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" && attr.Name.Space == "":
+				case elementSensitive || IsSensitiveKey(attr.Name.Local, keys):
+					if value != "" {
+						value = dm.keyMaskFunc(value)
+					}
+				default:
+					value = dm.maskString(st, value)
+				}
+				out.WriteString(" " + xmlName(attr.Name) + `="` + xmlAttrEscaper.Replace(value) + `"`)
+			}
+			out.WriteString(">")
+		case xml.EndElement:
+			if len(sensitive) > 0 {
+				sensitive = sensitive[:len(sensitive)-1]
+			}
+			out.WriteString("</" + xmlName(t.Name) + ">")
+		case xml.CharData:
+			text := string(t)
+			switch {
+			case strings.TrimSpace(text) == "":
+			case inSensitive():
+				text = dm.keyMaskFunc(text)
+			default:
+				text = dm.maskString(st, text)
+			}
+			out.WriteString(xmlTextEscaper.Replace(text))
+		case xml.Comment:
+			out.WriteString("<!--" + dm.maskString(st, string(t)) + "-->")
+		case xml.ProcInst:
+			out.WriteString("<?" + t.Target)
+			if len(t.Inst) > 0 {
+				out.WriteString(" " + string(t.Inst))
+			}
+			out.WriteString("?>")
+		case xml.Directive:
+			out.WriteString("<!" + string(t) + ">")
+		}
+	}
+
+	return out.Flush()
+}
+
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
@@ -0,0 +1,110 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendMask(t *testing.T) {
+	dst := []byte("log: ")
+	dst = masker.AppendMask(dst, []byte("card 4111 1111 1111 1111 paid"))
+	assert.Equal(t, "log: card 4111********1111 paid", string(dst))
+
+	dst = masker.AppendMask(dst[:0], []byte("no candidates here"))
+	assert.Equal(t, "no candidates here", string(dst))
+}
+
+func TestAppendMask_Method(t *testing.T) {
+	m := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithMaxStringLength(8),
+	).(*masker.DefaultMasker)
+
+	assert.Equal(t, "short", string(m.AppendMask(nil, []byte("short"))))
+	assert.Equal(t, masker.RedactedValue, string(m.AppendMask(nil, []byte("longer than the limit"))),
+		"the fast path does not bypass the length limit")
+}
+
+func TestAppendMask_MatchesMaskString(t *testing.T) {
+	m := masker.NewWithOpts(
+		masker.WithPatterns(append(masker.DefaultPatterns(),
+			masker.Pattern{Name: "word", Regex: `(?i)\bsecret\b`, MaskFunc: masker.Redact},
+			masker.Pattern{Name: "unicode", Regex: `ção\d+`, MaskFunc: masker.Redact},
+			masker.Pattern{Name: "negated", Regex: `#[^a-z\s]+#`, MaskFunc: masker.Redact},
+			masker.Pattern{Name: "kelvin", Regex: `(?i)k\d`, MaskFunc: masker.Redact},
+		)),
+		masker.WithURLCredentials(),
+		masker.WithKeyValueDetection(),
+	).(*masker.DefaultMasker)
+
+	inputs := []string{
+		"",
+		"nothing here",
+		"SECRET value",
+		"Secret",
+		"operação123",
+		"#\xff\xfe#",
+		"#É#",
+		"\u212a1",
+		"https://user:pw@example.com/?token=abc",
+		"PASSWORD=hunter2",
+		"4111 1111 1111 1111",
+	}
+
+	for _, input := range inputs {
+		expected := m.Mask(input)
+		assert.Equal(t, expected, string(m.AppendMask(nil, []byte(input))), input)
+	}
+	assert.Equal(t, "[REDACTED]", m.Mask("\u212a1"), "case folding reaches non-ASCII first bytes")
+	assert.Equal(t, "[REDACTED]", m.Mask("#\xff\xfe#"), "invalid UTF-8 is matched by negated classes")
+}
+
+func TestAppendMask_Allocations(t *testing.T) {
+	m := masker.New().(*masker.DefaultMasker)
+	dst := make([]byte, 0, 1024)
+
+	noCandidate := []byte("user logged in from the web dashboard")
+	allocs := testing.AllocsPerRun(100, func() {
+		dst = m.AppendMask(dst[:0], noCandidate)
+	})
+	assert.Zero(t, allocs, "inputs without candidate bytes do not allocate")
+
+	noMatch := []byte("order 12345 shipped in 3 boxes")
+	allocs = testing.AllocsPerRun(100, func() {
+		dst = m.AppendMask(dst[:0], noMatch)
+	})
+	assert.Zero(t, allocs, "candidates that do not match do not allocate")
+}
+
+func BenchmarkAppendMask(b *testing.B) {
+	m := masker.New().(*masker.DefaultMasker)
+	inputs := map[string][]byte{
+		"no_candidate": []byte(strings.Repeat("user logged in from the web dashboard ", 10)),
+		"no_match":     []byte(strings.Repeat("order 12345 shipped in 3 boxes ", 10)),
+		"match":        []byte(strings.Repeat("card 4111 1111 1111 1111 paid ", 10)),
+	}
+
+	for name, input := range inputs {
+		b.Run(name, func(b *testing.B) {
+			dst := make([]byte, 0, 2*len(input))
+			b.ReportAllocs()
+			b.SetBytes(int64(len(input)))
+			for i := 0; i < b.N; i++ {
+				dst = m.AppendMask(dst[:0], input)
+			}
+		})
+	}
+}
+
+func BenchmarkMaskString_NoCandidate(b *testing.B) {
+	m := masker.New()
+	input := strings.Repeat("user logged in from the web dashboard ", 10)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Mask(input)
+	}
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		regex   string
+		warning string
+	}{
+		{"nested quantifier", `(a+)+b`, "nested unbounded quantifier"},
+		{"large counted repetition", `\d{500}`, "large counted repetition"},
+		{"leading wildcard", `.*password=\w+`, "leading wildcard"},
+		{"invalid regex", `(unclosed`, "invalid regex"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := masker.LintPattern(tt.regex)
+			if assert.NotEmpty(t, warnings) {
+				assert.Contains(t, warnings[0], tt.warning)
+			}
+		})
+	}
+}
+
+func TestLintPattern_BuiltinsAreClean(t *testing.T) {
+	patterns := append(masker.DefaultPatterns(),
+		masker.EntropyPattern(masker.EntropyConfig{}),
+		masker.URLPattern(),
+		masker.KeyValuePattern(nil, nil),
+	)
+
+	for _, pattern := range patterns {
+		assert.Empty(t, masker.LintPattern(pattern.Regex), pattern.Name)
+	}
+}
+
+func TestLintPattern_WarnsAtConstruction(t *testing.T) {
+	var warnings []string
+	masker.NewWithOpts(
+		masker.WithWarningHandler(func(pattern, message string) {
+			warnings = append(warnings, pattern+": "+message)
+		}),
+		masker.WithCustomPattern("slow", `(\w+\s?)+$`, masker.Redact),
+	)
+
+	if assert.Len(t, warnings, 1) {
+		assert.Contains(t, warnings[0], "slow: nested unbounded quantifier")
+	}
+}
+
+func TestLintPattern_SilentWithoutHandler(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	masker.NewWithOpts(masker.WithCustomPattern("slow", `(\w+\s?)+$`, masker.Redact))
+
+	assert.Empty(t, logs.String())
+}
+
+func TestMaxStringLength_FailsClosed(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithMaxStringLength(64),
+	)
+	long := strings.Repeat("a", 100)
+
+	result, report := mask.(masker.ReportingMasker).MaskWithReport([]interface{}{"4111-1111-1111-1111", long})
+
+	assert.Equal(t, []interface{}{"4111********1111", masker.RedactedValue}, result)
+	if assert.Len(t, report.Findings, 2) {
+		assert.Equal(t, "max string length exceeded", report.Findings[1].Reason)
+		assert.Equal(t, "[1]", report.Findings[1].Path)
+	}
+}
+
+func TestMaxStringLength_FailsClosedForBytes(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithMaxStringLength(64),
+	)
+
+	result, report := mask.(masker.ReportingMasker).MaskWithReport([]byte(strings.Repeat("z", 100)))
+
+	assert.Equal(t, []byte(masker.RedactedValue), result)
+	if assert.Len(t, report.Findings, 1) {
+		assert.Equal(t, "max string length exceeded", report.Findings[0].Reason)
+	}
+}
+
+func TestTimeBudget_FailsClosed(t *testing.T) {
+	slow := masker.Pattern{
+		Name:  "slow",
+		Regex: `\d+`,
+		Validate: func(string) bool {
+			time.Sleep(20 * time.Millisecond)
+			return false
+		},
+		MaskFunc: masker.Redact,
+	}
+	mask := masker.NewWithOpts(
+		masker.WithPatterns([]masker.Pattern{slow}),
+		masker.WithTimeBudget(5*time.Millisecond),
+	)
+
+	result := mask.Mask([]interface{}{"order 1", "order 2", "no digits"})
+
+	assert.Equal(t, []interface{}{masker.RedactedValue, masker.RedactedValue, masker.RedactedValue}, result)
+}
+
+func TestTimeBudget_NotExceeded(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithTimeBudget(time.Second),
+	)
+
+	assert.Equal(t, "4111********1111", mask.Mask("4111-1111-1111-1111"))
+}
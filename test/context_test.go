@@ -0,0 +1,103 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func cvvPattern(require bool) masker.Pattern {
+	return masker.Pattern{
+		Name:           "cvv",
+		Regex:          `\b\d{3,4}\b`,
+		Keywords:       []string{"cvv", "cvc", "código de segurança"},
+		KeywordWindow:  12,
+		RequireKeyword: require,
+		MaskFunc: func(s string) string {
+			return "***"
+		},
+	}
+}
+
+func TestContext_RequireKeyword(t *testing.T) {
+	mask := masker.NewWithOpts(masker.WithPatterns([]masker.Pattern{cvvPattern(true)}))
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"keyword before", "cvv: 123", "cvv: ***"},
+		{"keyword after", "123 is the CVC", "*** is the CVC"},
+		{"accented keyword", "Código de segurança 4321", "Código de segurança ***"},
+		{"no keyword", "room 123", "room 123"},
+		{"keyword too far", "cvv is required for the order 123", "cvv is required for the order 123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mask.Mask(tt.input))
+		})
+	}
+}
+
+func TestContext_EnclosingMapKey(t *testing.T) {
+	mask := masker.NewWithOpts(masker.WithPatterns([]masker.Pattern{cvvPattern(true)}))
+
+	input := map[string]interface{}{
+		"card_cvv": "123",
+		"room":     "123",
+	}
+
+	result := mask.Mask(input).(map[string]interface{})
+
+	assert.Equal(t, "***", result["card_cvv"])
+	assert.Equal(t, "123", result["room"])
+}
+
+func TestContext_ConfidenceThreshold(t *testing.T) {
+	pattern := masker.Pattern{
+		Name:     "account",
+		Regex:    `\b\d{5,8}-\d\b`,
+		Keywords: []string{"conta", "account", "agência"},
+		MaskFunc: func(s string) string {
+			return "[ACCOUNT]"
+		},
+	}
+
+	t.Run("no threshold masks every candidate", func(t *testing.T) {
+		mask := masker.NewWithOpts(masker.WithPatterns([]masker.Pattern{pattern}))
+		assert.Equal(t, "ref [ACCOUNT]", mask.Mask("ref 12345-6"))
+	})
+
+	t.Run("threshold requires keyword boost", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns([]masker.Pattern{pattern}),
+			masker.WithMinConfidence(0.8),
+		)
+		assert.Equal(t, "ref 12345-6", mask.Mask("ref 12345-6"))
+		assert.Equal(t, "agência 0001 conta [ACCOUNT]", mask.Mask("agência 0001 conta 12345-6"))
+	})
+}
+
+func TestContext_ReportsConfidence(t *testing.T) {
+	pattern := cvvPattern(false)
+	pattern.Confidence = 0.3
+	pattern.KeywordBoost = 0.4
+	mask := masker.NewWithOpts(
+		masker.WithPatterns([]masker.Pattern{pattern}),
+		masker.WithMinConfidence(0.5),
+	)
+
+	result, report := mask.(masker.ReportingMasker).MaskWithReport("cvv 123, and the room number is 456")
+
+	assert.Equal(t, "cvv ***, and the room number is 456", result)
+	masked := report.Masked()
+	rejected := report.Rejected()
+	if assert.Len(t, masked, 1) && assert.Len(t, rejected, 1) {
+		assert.InDelta(t, 0.7, masked[0].Confidence, 0.0001)
+		assert.InDelta(t, 0.3, rejected[0].Confidence, 0.0001)
+		assert.Equal(t, "below confidence threshold", rejected[0].Reason)
+	}
+}
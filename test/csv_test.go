@@ -0,0 +1,73 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskCSV_ColumnRules(t *testing.T) {
+	input := "name,CPF,E-mail,notes\n" +
+		"Jane,123.456.789-00,jane@example.com,\"paid with 4111 1111 1111 1111,\nthanks\"\n" +
+		"John,987.654.321-00,john@example.com,\n"
+	var out bytes.Buffer
+
+	err := masker.MaskCSV(strings.NewReader(input), &out, masker.CSVOptions{
+		Columns: map[string]func(string) string{
+			"cpf":   masker.PartialMask(3, 2),
+			"email": masker.HashSHA256(""),
+		},
+	})
+	require.NoError(t, err)
+
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, "name,CPF,E-mail,notes", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "Jane,123*********00,"))
+	assert.Contains(t, lines[1], masker.HashSHA256("")("jane@example.com"))
+	assert.Contains(t, out.String(), "\"paid with 4111********1111,\nthanks\"")
+	assert.True(t, strings.HasPrefix(lines[3], "John,987*********00,"))
+	assert.True(t, strings.HasSuffix(lines[3], ","), "empty cells stay empty")
+}
+
+func TestMaskCSV_TSVAndSensitiveHeaders(t *testing.T) {
+	input := "user\tpassword\tcard\njoe\thunter2\t4111-1111-1111-1111\n"
+	var out bytes.Buffer
+
+	err := masker.MaskCSV(strings.NewReader(input), &out, masker.CSVOptions{Comma: '\t'},
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithSensitiveKeys("password"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, "user\tpassword\tcard\njoe\t[REDACTED]\t4111********1111\n", out.String())
+}
+
+func TestMaskCSV_DefaultSensitiveKeys(t *testing.T) {
+	var out bytes.Buffer
+
+	err := masker.MaskCSV(strings.NewReader("user,password\njoe,hunter2\n"), &out, masker.CSVOptions{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user,password\njoe,[REDACTED]\n", out.String())
+}
+
+func TestMaskCSV_NoHeader(t *testing.T) {
+	var out bytes.Buffer
+
+	err := masker.MaskCSV(strings.NewReader("4111111111111111,x\n"), &out, masker.CSVOptions{NoHeader: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, "4111********1111,x\n", out.String())
+}
+
+func TestMaskCSV_MalformedInput(t *testing.T) {
+	var out bytes.Buffer
+
+	err := masker.MaskCSV(strings.NewReader("a,b\n\"unterminated,c\n"), &out, masker.CSVOptions{})
+
+	assert.Error(t, err)
+}
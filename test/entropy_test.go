@@ -0,0 +1,124 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShannonEntropy(t *testing.T) {
+	assert.Equal(t, 0.0, masker.ShannonEntropy(""))
+	assert.Equal(t, 0.0, masker.ShannonEntropy("aaaaaaaa"))
+	assert.InDelta(t, 2.0, masker.ShannonEntropy("abcd"), 0.0001)
+	assert.InDelta(t, 4.0, masker.ShannonEntropy("0123456789abcdef"), 0.0001)
+}
+
+func TestEntropy_DetectsUnknownSecrets(t *testing.T) {
+	mask := masker.NewWithOpts(masker.WithEntropyDetection(masker.EntropyConfig{}))
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "provider api key",
+			input:    "key=sk_test_4eC39HqLyjWDarjtT1zdp7dc",
+			expected: "key=[REDACTED]",
+		},
+		{
+			name:     "base64 secret",
+			input:    "secret wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY used",
+			expected: "secret [REDACTED] used",
+		},
+		{
+			name:     "rest path",
+			input:    "GET /api/v1/customers/9f8e7d6c/payment-methods/default HTTP/1.1",
+			expected: "GET /api/v1/customers/9f8e7d6c/payment-methods/default HTTP/1.1",
+		},
+		{
+			name:     "file path",
+			input:    "open /var/lib/payments/releases/2024-06-01T10/config/settings.yaml failed",
+			expected: "open /var/lib/payments/releases/2024-06-01T10/config/settings.yaml failed",
+		},
+		{
+			name:     "relative file path",
+			input:    "at src/internal/handlers/PaymentMethodsController_v2.go:42",
+			expected: "at src/internal/handlers/PaymentMethodsController_v2.go:42",
+		},
+		{
+			name:     "secret inside a path",
+			input:    "GET /reset/Zx9Qp2Lm7Rt4Vw8Ks1aB3cD/confirm",
+			expected: "GET /reset/[REDACTED]/confirm",
+		},
+		{
+			name:     "low entropy identifier",
+			input:    "this-is-a-long-kebab-case-name",
+			expected: "this-is-a-long-kebab-case-name",
+		},
+		{
+			name:     "short token",
+			input:    "abc123XYZ",
+			expected: "abc123XYZ",
+		},
+		{
+			name:     "long number",
+			input:    "98127361928736192837",
+			expected: "98127361928736192837",
+		},
+		{
+			name:     "uuid allowlisted",
+			input:    "b6fedaf6-02cf-4ce4-bbaa-1af85e822e30",
+			expected: "b6fedaf6-02cf-4ce4-bbaa-1af85e822e30",
+		},
+		{
+			name:     "sha256 allowlisted",
+			input:    "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+			expected: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mask.Mask(tt.input))
+		})
+	}
+}
+
+func TestEntropy_CustomAllowlist(t *testing.T) {
+	cfg := masker.EntropyConfig{
+		MinLength:       16,
+		Base64Threshold: 4.0,
+		Allowlist:       []string{`^TXN-[0-9A-Z]+$`},
+	}
+	mask := masker.NewWithOpts(masker.WithEntropyDetection(cfg))
+
+	const sha256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	assert.Equal(t, "TXN-9F3K2L8Q7W1Z4X6C", mask.Mask("TXN-9F3K2L8Q7W1Z4X6C"))
+	assert.Equal(t, sha256, mask.Mask(sha256), "the default allowlist still applies")
+	assert.Equal(t, "[REDACTED]", mask.Mask("Zx9Qp2Lm7Rt4Vw8Ks1"))
+
+	cfg.ReplaceDefaults = true
+	mask = masker.NewWithOpts(masker.WithEntropyDetection(cfg))
+
+	assert.Equal(t, "TXN-9F3K2L8Q7W1Z4X6C", mask.Mask("TXN-9F3K2L8Q7W1Z4X6C"))
+	assert.Equal(t, "[REDACTED]", mask.Mask(sha256))
+}
+
+func TestEntropy_SpecificPatternsWin(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithCustomPattern("stripe_key", `sk_(?:test|live)_[A-Za-z0-9]{24}`, func(s string) string {
+			return s[:8] + "************************"
+		}),
+		masker.WithEntropyDetection(masker.EntropyConfig{}),
+		masker.WithOverlapMode(masker.OverlapPriority),
+	)
+
+	result, report := mask.(masker.ReportingMasker).MaskWithReport("sk_test_4eC39HqLyjWDarjtT1zdp7dc")
+
+	assert.Equal(t, "sk_test_************************", result)
+	if assert.Len(t, report.Masked(), 1) {
+		assert.Equal(t, "stripe_key", report.Masked()[0].Pattern)
+	}
+}
@@ -0,0 +1,80 @@
+package test
+
+import (
+	"net/url"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskForm(t *testing.T) {
+	form := url.Values{
+		"card":     {"4111 1111 1111 1111"},
+		"password": {"hunter2"},
+		"tags":     {"a", "b"},
+		"token":    {""},
+	}
+
+	result := masker.MaskForm(form)
+
+	assert.Equal(t, []string{"4111********1111"}, result["card"])
+	assert.Equal(t, []string{"[REDACTED]"}, result["password"])
+	assert.Equal(t, []string{"a", "b"}, result["tags"])
+	assert.Equal(t, []string{""}, result["token"])
+	assert.Equal(t, []string{"hunter2"}, form["password"], "input must not be modified")
+}
+
+func TestMaskForm_CustomKeys(t *testing.T) {
+	form := url.Values{"cpf": {"123.456.789-00"}, "password": {"hunter2"}}
+
+	result := masker.MaskForm(form, masker.WithSensitiveKeys("cpf"))
+
+	assert.Equal(t, []string{"[REDACTED]"}, result["cpf"])
+	assert.Equal(t, []string{"hunter2"}, result["password"])
+}
+
+func TestMaskQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "order and encoding preserved",
+			input:    "z=1&client_secret=abc%2Fdef&a=hello+world&card=4111-1111-1111-1111",
+			expected: "z=1&client_secret=%5BREDACTED%5D&a=hello+world&card=4111%2A%2A%2A%2A%2A%2A%2A%2A1111",
+		},
+		{
+			name:     "leading question mark",
+			input:    "?access_token=xyz&page=2",
+			expected: "?access_token=%5BREDACTED%5D&page=2",
+		},
+		{
+			name:     "repeated and valueless keys",
+			input:    "flag&password=a&password=b&password=",
+			expected: "flag&password=%5BREDACTED%5D&password=%5BREDACTED%5D&password=",
+		},
+		{
+			name:     "empty",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, masker.MaskQuery(tt.input))
+		})
+	}
+}
+
+func TestMaskQuery_RoundTrips(t *testing.T) {
+	masked := masker.MaskQuery("api_key=k1&redirect=https%3A%2F%2Fexample.com%2Fcb")
+
+	values, err := url.ParseQuery(masked)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "[REDACTED]", values.Get("api_key"))
+	assert.Equal(t, "https://example.com/cb", values.Get("redirect"))
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type chargeRequest struct {
+	Customer string `json:"customer"`
+	Card     string `json:"card"`
+	Password string `json:"password"`
+	Internal string `json:"-"`
+}
+
+func TestSprintf(t *testing.T) {
+	req := chargeRequest{Customer: "Jane", Card: "4111-1111-1111-1111", Internal: "4111111111111111"}
+
+	out := masker.Sprintf("req=%+v card=%s", req, "5500 0000 0000 0004")
+
+	assert.Contains(t, out, "req={Customer:Jane Card:4111********1111 Password: Internal:4111********1111}")
+	assert.Contains(t, out, "card=5500********0004")
+	assert.NotContains(t, out, "4111111111111111")
+}
+
+func TestSprintf_PointersAndSecondPass(t *testing.T) {
+	req := &chargeRequest{Customer: "Jane", Card: "4111111111111111"}
+
+	out := masker.Sprintf("%v / raw %d%d", req, 41111111, 11111111)
+
+	assert.NotContains(t, out, "4111111111111111")
+	assert.Contains(t, out, "raw 4111********1111")
+}
+
+func TestSprintf_KeyRules(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithSensitiveKeys("password"),
+	).(*masker.DefaultMasker)
+
+	out := mask.Sprintf("%v", chargeRequest{Customer: "Jane", Password: "hunter2"})
+
+	assert.Equal(t, "{Jane  [REDACTED] }", out)
+}
+
+type account struct {
+	id     int
+	owner  string
+	Card   string
+	Limits map[string]float64
+	Next   *account
+}
+
+func TestSprintf_KeepsArgumentShape(t *testing.T) {
+	acct := &account{id: 7, owner: "Jane", Card: "4111111111111111", Limits: map[string]float64{"daily": 500}}
+
+	assert.Equal(t, "{3}", masker.Sprintf("%v", struct{ n int }{3}))
+	assert.Equal(t, "&{7 Jane 4111********1111 map[daily:500] <nil>}", masker.Sprintf("%v", acct))
+	assert.Equal(t, "&{id:7 owner:Jane Card:4111********1111 Limits:map[daily:500] Next:<nil>}", masker.Sprintf("%+v", acct))
+	assert.Equal(t, "4111111111111111", acct.Card, "the argument must not be modified")
+}
+
+func TestSprintf_UnmarshalableArgument(t *testing.T) {
+	type job struct {
+		Name string
+		Done chan bool
+	}
+
+	out := masker.Sprintf("%v", job{Name: "card 4111111111111111"})
+
+	assert.Equal(t, "{card 4111********1111 <nil>}", out)
+}
+
+func TestSprintf_StrictModeUsesJSON(t *testing.T) {
+	mask := masker.NewWithOpts(masker.WithPatterns(masker.DefaultPatterns()), masker.WithStrictMode()).(*masker.DefaultMasker)
+
+	out := mask.Sprintf("%v", chargeRequest{Customer: "Jane", Card: "4111111111111111"})
+
+	assert.Equal(t, "map[card:4111********1111 customer:Jane password:]", out)
+}
+
+func TestSprintf_SensitiveAndStringer(t *testing.T) {
+	out := masker.Sprintf("%v %v", masker.NewSensitive("hunter2"), cardHolder{card: "4111111111111111"})
+
+	assert.Equal(t, "[REDACTED] holder with card 4111********1111", out)
+}
+
+func TestFprintf(t *testing.T) {
+	var buf bytes.Buffer
+
+	n, err := masker.Fprintf(&buf, "card %s\n", "4111 1111 1111 1111")
+
+	require.NoError(t, err)
+	assert.Equal(t, "card 4111********1111\n", buf.String())
+	assert.Equal(t, buf.Len(), n)
+}
+
+func TestErrorf(t *testing.T) {
+	inner := &paymentError{Code: "05"}
+
+	err := masker.Errorf("charging %s: %w", "4111111111111111", inner)
+
+	assert.Equal(t, "charging 4111********1111: payment 05 declined for 4111********1111", err.Error())
+	assert.True(t, errors.Is(err, inner))
+	var target *paymentError
+	assert.True(t, errors.As(err, &target))
+}
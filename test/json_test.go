@@ -0,0 +1,44 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskJSON(t *testing.T) {
+	input := []byte(`{"card":"4111 1111 1111 1111","amount":12345678901234567890,"note":"<b>&</b>","items":[{"pan":"5500-0000-0000-0004"}]}`)
+
+	result, err := masker.MaskJSON(input)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"card":"4111********1111","amount":12345678901234567890,"note":"<b>&</b>","items":[{"pan":"5500********0004"}]}`, string(result))
+	assert.Contains(t, string(result), "12345678901234567890", "numbers keep their precision")
+	assert.Contains(t, string(result), "<b>&</b>", "HTML is not escaped")
+}
+
+func TestMaskJSON_KeyRules(t *testing.T) {
+	result, err := masker.MaskJSON([]byte(`{"user":"joe","password":"hunter2"}`), masker.WithSensitiveKeys("password"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"user":"joe","password":"[REDACTED]"}`, string(result))
+}
+
+func TestMaskJSON_Invalid(t *testing.T) {
+	_, err := masker.MaskJSON([]byte(`{"card":`))
+
+	assert.Error(t, err)
+}
+
+func TestMaskJSON_TrailingData(t *testing.T) {
+	for _, input := range []string{`{"a":1} garbage`, "{\"a\":1}\n{\"a\":2}\n", `[1] ]`} {
+		_, err := masker.MaskJSON([]byte(input))
+		assert.Error(t, err, input)
+	}
+
+	result, err := masker.MaskJSON([]byte("{\"a\":1}\n\t "))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(result))
+}
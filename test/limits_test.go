@@ -0,0 +1,119 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func nestedMap(depth int) map[string]interface{} {
+	root := map[string]interface{}{}
+	current := root
+	for i := 0; i < depth; i++ {
+		next := map[string]interface{}{}
+		current["child"] = next
+		current = next
+	}
+	current["card"] = "4111-1111-1111-1111"
+	return root
+}
+
+func TestLimits_SelfReferencingMap(t *testing.T) {
+	input := map[string]interface{}{
+		"card": "4111-1111-1111-1111",
+	}
+	input["self"] = input
+
+	result, report := masker.New().(masker.ReportingMasker).MaskWithReport(input)
+	masked := result.(map[string]interface{})
+
+	assert.Equal(t, "4111********1111", masked["card"])
+	assert.Equal(t, masker.TruncatedValue, masked["self"])
+	found := false
+	for _, f := range report.Findings {
+		if f.Pattern == masker.LimitPattern && f.Reason == "cycle detected" {
+			found = true
+			assert.Equal(t, "self", f.Path)
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestLimits_SelfReferencingSlice(t *testing.T) {
+	input := make([]interface{}, 2)
+	input[0] = "4111-1111-1111-1111"
+	input[1] = input
+
+	result := masker.MaskData(input).([]interface{})
+
+	assert.Equal(t, "4111********1111", result[0])
+	assert.Equal(t, masker.TruncatedValue, result[1])
+}
+
+func TestLimits_SharedValuesAreNotCycles(t *testing.T) {
+	shared := map[string]interface{}{"card": "4111-1111-1111-1111"}
+	input := map[string]interface{}{"a": shared, "b": shared}
+
+	result := masker.MaskData(input).(map[string]interface{})
+
+	assert.Equal(t, "4111********1111", result["a"].(map[string]interface{})["card"])
+	assert.Equal(t, "4111********1111", result["b"].(map[string]interface{})["card"])
+}
+
+func TestLimits_PointerCycle(t *testing.T) {
+	var value interface{}
+	value = &value
+
+	assert.NotPanics(t, func() {
+		masker.MaskData(value)
+	})
+
+	card := "4111-1111-1111-1111"
+	assert.Equal(t, "4111********1111", masker.MaskData(&card))
+}
+
+func TestLimits_MaxDepth(t *testing.T) {
+	t.Run("default depth protects against hostile nesting", func(t *testing.T) {
+		assert.NotPanics(t, func() {
+			masker.MaskData(nestedMap(100000))
+		})
+	})
+
+	t.Run("truncate", func(t *testing.T) {
+		mask := masker.NewWithOpts(masker.WithPatterns(masker.DefaultPatterns()), masker.WithMaxDepth(2))
+		result := mask.Mask(nestedMap(3)).(map[string]interface{})
+		child := result["child"].(map[string]interface{})
+		assert.Equal(t, masker.TruncatedValue, child["child"])
+	})
+
+	t.Run("redact", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns(masker.DefaultPatterns()),
+			masker.WithMaxDepth(1),
+			masker.WithLimitPolicy(masker.LimitRedact),
+		)
+		result := mask.Mask(nestedMap(1)).(map[string]interface{})
+		assert.Equal(t, masker.RedactedValue, result["child"])
+	})
+}
+
+func TestLimits_MaxElements(t *testing.T) {
+	input := []interface{}{"a", "b", "c", "d", "e"}
+
+	t.Run("truncate", func(t *testing.T) {
+		mask := masker.NewWithOpts(masker.WithPatterns(masker.DefaultPatterns()), masker.WithMaxElements(3))
+		result, report := mask.(masker.ReportingMasker).MaskWithReport(input)
+		assert.Equal(t, []interface{}{"a", "b", masker.TruncatedValue}, result)
+		assert.NotEmpty(t, report.Findings)
+	})
+
+	t.Run("redact", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns(masker.DefaultPatterns()),
+			masker.WithMaxElements(3),
+			masker.WithLimitPolicy(masker.LimitRedact),
+		)
+		assert.Equal(t, []interface{}{masker.RedactedValue}, mask.Mask(input))
+	})
+}
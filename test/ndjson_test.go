@@ -0,0 +1,76 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaskNDJSON(t *testing.T) {
+	input := strings.Join([]string{
+		`{"level":"info","card":"4111 1111 1111 1111","password":"hunter2"}`,
+		``,
+		`plain text card 4111 1111 1111 1111`,
+		`{"truncated":`,
+		`42`,
+		`{"msg":"last line without newline"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	result, err := masker.MaskNDJSON(strings.NewReader(input), &out,
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithSensitiveKeys("password"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, strings.Join([]string{
+		`{"card":"4111********1111","level":"info","password":"[REDACTED]"}`,
+		``,
+		`plain text card 4111********1111`,
+		`{"truncated":`,
+		`42`,
+		`{"msg":"last line without newline"}`,
+	}, "\n"), out.String())
+
+	assert.Equal(t, 6, result.Lines)
+	require.Len(t, result.Malformed, 2)
+	assert.Equal(t, 3, result.Malformed[0].Line)
+	assert.Equal(t, 4, result.Malformed[1].Line)
+	assert.Error(t, result.Malformed[1].Err)
+}
+
+func TestMaskNDJSON_PreservesLineEndings(t *testing.T) {
+	input := "{\"a\":\"4111 1111 1111 1111\"}\r\n\r\n{\"b\":1}\r\n"
+
+	var out bytes.Buffer
+	result, err := masker.MaskNDJSON(strings.NewReader(input), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, "{\"a\":\"4111********1111\"}\r\n\r\n{\"b\":1}\r\n", out.String())
+	assert.Equal(t, 3, result.Lines)
+	assert.Empty(t, result.Malformed)
+}
+
+func TestMaskNDJSON_TrailingGarbageIsMalformed(t *testing.T) {
+	var out bytes.Buffer
+	result, err := masker.MaskNDJSON(strings.NewReader(`{"a":1} {"b":2}`+"\n"), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"a":1} {"b":2}`+"\n", out.String(), "lines are never merged or split")
+	require.Len(t, result.Malformed, 1)
+}
+
+func TestMaskNDJSON_LongLines(t *testing.T) {
+	long := `{"data":"` + strings.Repeat("x", 1<<20) + `"}` + "\n"
+
+	var out bytes.Buffer
+	result, err := masker.MaskNDJSON(strings.NewReader(long+long), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Lines)
+	assert.Equal(t, long+long, out.String())
+}
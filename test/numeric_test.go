@@ -0,0 +1,96 @@
+package test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumeric_DisabledByDefault(t *testing.T) {
+	input := map[string]interface{}{
+		"card": int64(4111111111111111),
+	}
+
+	result := masker.MaskData(input).(map[string]interface{})
+
+	assert.Equal(t, int64(4111111111111111), result["card"])
+}
+
+func TestNumeric_MasksCardNumbers(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithNumericMasking(masker.NumericAll),
+	)
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected interface{}
+	}{
+		{"int64", int64(4111111111111111), "4111********1111"},
+		{"uint64", uint64(5500000000000004), "5500********0004"},
+		{"int", 4111111111111111, "4111********1111"},
+		{"float64 from json", float64(4111111111111111), "4111********1111"},
+		{"json.Number", json.Number("4111111111111111"), "4111********1111"},
+		{"small number unchanged", int64(42), int64(42)},
+		{"amount unchanged", 150.75, 150.75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mask.Mask(tt.input))
+		})
+	}
+}
+
+func TestNumeric_KindSelection(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithNumericMasking(masker.NumericJSONNumber),
+	)
+
+	assert.Equal(t, int64(4111111111111111), mask.Mask(int64(4111111111111111)))
+	assert.Equal(t, "4111********1111", mask.Mask(json.Number("4111111111111111")))
+}
+
+func TestNumeric_Sentinel(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithNumericMasking(masker.NumericAll),
+		masker.WithNumericSentinel(int64(0)),
+		masker.WithSensitiveKeys("pin"),
+	)
+
+	input := map[string]interface{}{
+		"card":   uint64(4111111111111111),
+		"pin":    1234,
+		"amount": 99,
+	}
+
+	result := mask.Mask(input).(map[string]interface{})
+
+	assert.Equal(t, int64(0), result["card"])
+	assert.Equal(t, int64(0), result["pin"])
+	assert.Equal(t, 99, result["amount"])
+}
+
+func TestNumeric_DecodedWithUseNumber(t *testing.T) {
+	decoder := json.NewDecoder(strings.NewReader(`{"payment":{"pan":4111111111111111,"installments":3}}`))
+	decoder.UseNumber()
+	var payload map[string]interface{}
+	require.NoError(t, decoder.Decode(&payload))
+
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithNumericMasking(masker.NumericJSONNumber),
+	)
+	result := mask.Mask(payload).(map[string]interface{})
+	payment := result["payment"].(map[string]interface{})
+
+	assert.Equal(t, "4111********1111", payment["pan"])
+	assert.Equal(t, json.Number("3"), payment["installments"])
+}
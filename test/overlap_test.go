@@ -0,0 +1,109 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func overlapPatterns(phonePriority, cardPriority int) []masker.Pattern {
+	return []masker.Pattern{
+		{
+			Name:     "phone",
+			Regex:    `\d{4,5}-?\d{4}`,
+			Priority: phonePriority,
+			MaskFunc: func(s string) string {
+				return "[PHONE]"
+			},
+		},
+		{
+			Name:     "credit_card",
+			Regex:    `\b(?:\d[ -]*?){13,16}\d\b`,
+			Priority: cardPriority,
+			MaskFunc: func(s string) string {
+				return "[CARD]"
+			},
+		},
+	}
+}
+
+func TestOverlap_FirstMatchKeepsDeclarationOrder(t *testing.T) {
+	mask := masker.NewWithOpts(masker.WithPatterns(overlapPatterns(0, 10)))
+
+	result := mask.Mask("card 4111111111111111").(string)
+
+	assert.Equal(t, "card [PHONE]1111111", result)
+}
+
+func TestOverlap_LeftmostLongest(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(overlapPatterns(0, 0)),
+		masker.WithOverlapMode(masker.OverlapLeftmostLongest),
+	)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"card wins over phone", "card 4111111111111111", "card [CARD]"},
+		{"phone alone", "call 99999-9999", "call [PHONE]"},
+		{"both in one string", "4111 1111 1111 1111 or 99999-9999", "[CARD] or [PHONE]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, mask.Mask(tt.input).(string))
+		})
+	}
+}
+
+func TestOverlap_LeftmostLongestUsesPriorityOnTies(t *testing.T) {
+	patterns := []masker.Pattern{
+		{Name: "generic", Regex: `\d{6}`, MaskFunc: func(s string) string { return "[GENERIC]" }},
+		{Name: "otp", Regex: `\d{6}`, Priority: 5, MaskFunc: func(s string) string { return "[OTP]" }},
+	}
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(patterns),
+		masker.WithOverlapMode(masker.OverlapLeftmostLongest),
+	)
+
+	assert.Equal(t, "code [OTP]", mask.Mask("code 123456").(string))
+}
+
+func TestOverlap_Priority(t *testing.T) {
+	t.Run("higher priority wins even when shorter", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns(overlapPatterns(10, 0)),
+			masker.WithOverlapMode(masker.OverlapPriority),
+		)
+		assert.Equal(t, "[PHONE]1111111", mask.Mask("4111111111111111").(string))
+	})
+
+	t.Run("most specific detector wins", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns(overlapPatterns(0, 10)),
+			masker.WithOverlapMode(masker.OverlapPriority),
+		)
+		assert.Equal(t, "[CARD] and [PHONE]", mask.Mask("4111111111111111 and 99999-9999").(string))
+	})
+
+	t.Run("equal priority prefers longest match", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns(overlapPatterns(0, 0)),
+			masker.WithOverlapMode(masker.OverlapPriority),
+		)
+		assert.Equal(t, "[CARD]", mask.Mask("4111111111111111").(string))
+	})
+}
+
+func TestWithPriorityPattern(t *testing.T) {
+	config := masker.Config{}
+	masker.WithPriorityPattern("otp", `\d{6}`, 7, func(s string) string {
+		return "******"
+	})(&config)
+
+	assert.Len(t, config.Patterns, 1)
+	assert.Equal(t, 7, config.Patterns[0].Priority)
+}
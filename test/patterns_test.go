@@ -22,6 +22,14 @@ func TestDefaultPatterns(t *testing.T) {
 	assert.True(t, patternNames["credit_card"], "Should have credit_card pattern")
 }
 
+func TestDefaultPatterns_MaskFuncShortInput(t *testing.T) {
+	card := masker.DefaultPatterns()[0]
+
+	assert.Equal(t, "123", card.MaskFunc("123"))
+	assert.Equal(t, "4111-1111-1111", card.MaskFunc("4111-1111-1111"))
+	assert.Equal(t, "4111********1111", card.MaskFunc("4111 1111 1111 1111"))
+}
+
 func TestCleanNumber(t *testing.T) {
 	tests := []struct {
 		name     string
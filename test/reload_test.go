@@ -0,0 +1,108 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadableMasker_Update(t *testing.T) {
+	rm, err := masker.NewReloadable(masker.Config{Patterns: []masker.Pattern{
+		{Name: "order", Regex: `ORD-\d+`, MaskFunc: masker.Redact},
+	}})
+	require.NoError(t, err)
+
+	var _ masker.ReportingMasker = rm
+	assert.Equal(t, "order [REDACTED]", rm.Mask("order ORD-1"))
+
+	err = rm.Update(masker.Config{Patterns: []masker.Pattern{
+		{Name: "broken", Regex: `(unclosed`, MaskFunc: masker.Redact},
+	}})
+	assert.ErrorContains(t, err, "patterns[0] (broken).regex")
+	assert.Equal(t, "order [REDACTED]", rm.Mask("order ORD-1"), "previous rules are kept")
+
+	require.NoError(t, rm.Update(masker.Config{Patterns: []masker.Pattern{
+		{Name: "ticket", Regex: `TCK-\d+`, MaskFunc: masker.Redact},
+	}}))
+	assert.Equal(t, "order ORD-1", rm.Mask("order ORD-1"))
+	assert.Equal(t, "ticket [REDACTED]", rm.Mask("ticket TCK-1"))
+}
+
+func TestWatchConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "masking.yaml")
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	write("patterns:\n  - name: order\n    regex: 'ORD-\\d+'\n")
+
+	var mu sync.Mutex
+	var errs []error
+	reloads := 0
+	rm, err := masker.WatchConfig(path, masker.WatchOptions{
+		Interval: 5 * time.Millisecond,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+		OnReload: func(masker.Config) {
+			mu.Lock()
+			defer mu.Unlock()
+			reloads++
+		},
+	})
+	require.NoError(t, err)
+	defer rm.Close()
+
+	assert.Equal(t, "[REDACTED]", rm.Mask("ORD-1"))
+
+	write("patterns:\n  - name: ticket\n    regex: 'TCK-\\d+'\n")
+	require.Eventually(t, func() bool {
+		return rm.Mask("TCK-1") == "[REDACTED]"
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "ORD-1", rm.Mask("ORD-1"))
+
+	write("patterns:\n  - name: ticket\n    regex: '(unclosed'\n")
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(errs) > 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, "[REDACTED]", rm.Mask("TCK-1"), "invalid file keeps the previous rules")
+
+	mu.Lock()
+	assert.ErrorContains(t, errs[0], "patterns[0].regex")
+	assert.Equal(t, 2, reloads)
+	mu.Unlock()
+}
+
+func TestWatchConfig_InitialLoadFails(t *testing.T) {
+	_, err := masker.WatchConfig(filepath.Join(t.TempDir(), "missing.yaml"), masker.WatchOptions{})
+	assert.Error(t, err)
+}
+
+func TestReloadableMasker_ConcurrentSwap(t *testing.T) {
+	rm, err := masker.NewReloadable(masker.DefaultConfig())
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				rm.Mask("card 4111 1111 1111 1111")
+			}
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		require.NoError(t, rm.Update(masker.DefaultConfig()))
+	}
+	wg.Wait()
+}
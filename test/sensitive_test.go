@@ -0,0 +1,89 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Customer struct {
+	Name     string                       `json:"name"`
+	Password masker.Sensitive[string]     `json:"password"`
+	PIN      *masker.Sensitive[int]       `json:"pin,omitempty"`
+	Card     masker.Sensitive[cardRecord] `json:"card"`
+}
+
+func newCustomer() Customer {
+	pin := masker.NewSensitive(1234)
+	return Customer{
+		Name:     "Jane",
+		Password: masker.NewSensitive("hunter2"),
+		PIN:      &pin,
+		Card:     masker.NewSensitive(cardRecord{Holder: "Jane", Number: "4111111111111111"}),
+	}
+}
+
+func TestSensitive_Reveal(t *testing.T) {
+	customer := newCustomer()
+
+	assert.Equal(t, "hunter2", customer.Password.Reveal())
+	assert.Equal(t, 1234, customer.PIN.Reveal())
+	assert.Equal(t, "4111111111111111", customer.Card.Reveal().Number)
+}
+
+func TestSensitive_Formatting(t *testing.T) {
+	customer := newCustomer()
+
+	for _, format := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%d"} {
+		t.Run(format, func(t *testing.T) {
+			out := fmt.Sprintf(format, customer)
+			assert.NotContains(t, out, "hunter2")
+			assert.NotContains(t, out, "4111111111111111")
+			assert.NotContains(t, out, "1234")
+		})
+	}
+
+	assert.Equal(t, "[REDACTED]", customer.Password.String())
+	assert.Equal(t, "masker.Sensitive[string]{[REDACTED]}", fmt.Sprintf("%#v", customer.Password))
+	assert.Equal(t, `"[REDACTED]"`, fmt.Sprintf("%q", customer.Password))
+}
+
+func TestSensitive_JSON(t *testing.T) {
+	data, err := json.Marshal(newCustomer())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{"name":"Jane","password":"[REDACTED]","pin":"[REDACTED]","card":"[REDACTED]"}`, string(data))
+
+	var decoded Customer
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"Joe","password":"s3cr3t","pin":42}`), &decoded))
+	assert.Equal(t, "s3cr3t", decoded.Password.Reveal())
+	assert.Equal(t, 42, decoded.PIN.Reveal())
+}
+
+func TestSensitive_TextAndSlog(t *testing.T) {
+	secret := masker.NewSensitive("hunter2")
+
+	text, err := secret.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "[REDACTED]", string(text))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	logger.Info("login", "password", secret, "customer", newCustomer())
+
+	assert.NotContains(t, buf.String(), "hunter2")
+	assert.Contains(t, buf.String(), `"password":"[REDACTED]"`)
+}
+
+func TestSensitive_MaskedByTraversal(t *testing.T) {
+	result := masker.MaskDataInterface(newCustomer()).(map[string]interface{})
+
+	assert.Equal(t, "Jane", result["name"])
+	assert.Equal(t, "[REDACTED]", result["password"])
+}
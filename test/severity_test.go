@@ -0,0 +1,53 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := masker.ParseSeverity("HIGH")
+	require.NoError(t, err)
+	assert.Equal(t, masker.SeverityHigh, severity)
+	assert.Equal(t, "high", severity.String())
+
+	_, err = masker.ParseSeverity("severe")
+	assert.Error(t, err)
+}
+
+func TestFindingSeverity(t *testing.T) {
+	m := masker.NewWithOpts(
+		masker.WithPatterns([]masker.Pattern{
+			{Name: "ticket", Regex: `TCK-\d+`, MaskFunc: masker.Redact},
+			{Name: "key", Regex: `KEY-\d+`, MaskFunc: masker.Redact, Severity: masker.SeverityCritical},
+		}),
+		masker.WithSensitiveKeys("password"),
+	)
+
+	_, report := m.(masker.ReportingMasker).MaskWithReport(map[string]interface{}{
+		"note":     "TCK-1 KEY-2",
+		"password": "x",
+	})
+
+	severities := make(map[string]masker.Severity)
+	for _, f := range report.Masked() {
+		severities[f.Pattern] = f.Severity
+	}
+	assert.Equal(t, map[string]masker.Severity{
+		"ticket":                   masker.DefaultSeverity,
+		"key":                      masker.SeverityCritical,
+		masker.SensitiveKeyPattern: masker.SeverityHigh,
+	}, severities)
+}
+
+func TestConfigSeverity(t *testing.T) {
+	config, err := masker.ConfigFromYAML([]byte("patterns:\n  - name: a\n    regex: a\n    severity: critical\n"))
+	require.NoError(t, err)
+	assert.Equal(t, masker.SeverityCritical, config.Patterns[0].Severity)
+
+	_, err = masker.ConfigFromYAML([]byte("patterns:\n  - name: a\n    regex: a\n    severity: severe\n"))
+	assert.ErrorContains(t, err, "patterns[0].severity")
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrategies(t *testing.T) {
+	assert.Equal(t, "abc", masker.Keep("abc"))
+	assert.Equal(t, "N/A", masker.Replace("N/A")("abc"))
+	assert.Equal(t, "[REDACTED]", masker.Redact("abc"))
+}
+
+func TestHashSHA256(t *testing.T) {
+	hash := masker.HashSHA256("")
+
+	assert.Equal(t, "ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad", hash("abc"))
+	assert.Equal(t, hash("abc"), hash("abc"), "hashing is deterministic")
+	assert.NotEqual(t, hash("abc"), masker.HashSHA256("pepper")("abc"), "salt changes the digest")
+}
+
+func TestPartialMask(t *testing.T) {
+	tests := []struct {
+		name        string
+		first, last int
+		input       string
+		expected    string
+	}{
+		{"keeps ends", 3, 2, "12345678900", "123******00"},
+		{"multibyte", 1, 1, "joão", "j**o"},
+		{"shorter than kept parts", 4, 4, "1234", "****"},
+		{"empty", 2, 2, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, masker.PartialMask(tt.first, tt.last)(tt.input))
+		})
+	}
+}
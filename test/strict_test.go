@@ -0,0 +1,101 @@
+package test
+
+import (
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+type CardNumber string
+
+type cardRecord struct {
+	Holder string `json:"holder"`
+	Number string `json:"number"`
+}
+
+type notifier struct {
+	Events chan string `json:"events"`
+}
+
+type linkedNode struct {
+	Card string      `json:"card"`
+	Next *linkedNode `json:"next"`
+}
+
+func TestStrictMode_DefaultPassesThrough(t *testing.T) {
+	ch := make(chan int)
+
+	assert.Equal(t, ch, masker.MaskData(ch))
+	assert.Equal(t, map[string]interface{}{}, masker.MaskDataInterface(ch))
+}
+
+func TestStrictMode_RedactsUninspectableValues(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithStrictMode(),
+	)
+
+	input := map[string]interface{}{
+		"channel":  make(chan int),
+		"callback": func() {},
+		"complex":  complex(1, 2),
+		"notifier": notifier{Events: make(chan string)},
+		"amount":   150.75,
+		"active":   true,
+	}
+
+	result, report := mask.(masker.ReportingMasker).MaskWithReport(input)
+	masked := result.(map[string]interface{})
+
+	assert.Equal(t, masker.RedactedValue, masked["channel"])
+	assert.Equal(t, masker.RedactedValue, masked["callback"])
+	assert.Equal(t, masker.RedactedValue, masked["complex"])
+	assert.Equal(t, masker.RedactedValue, masked["notifier"])
+	assert.Equal(t, 150.75, masked["amount"])
+	assert.Equal(t, true, masked["active"])
+
+	reasons := make(map[string]string)
+	for _, f := range report.Findings {
+		if f.Pattern == masker.UnsupportedPattern {
+			reasons[f.Path] = f.Reason
+		}
+	}
+	assert.Equal(t, "unsupported type chan int", reasons["channel"])
+	assert.Equal(t, "unsupported type func()", reasons["callback"])
+	assert.Contains(t, reasons["notifier"], "marshal error")
+}
+
+func TestStrictMode_InspectsStructsAndNamedTypes(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithStrictMode(),
+	)
+
+	input := map[string]interface{}{
+		"record": &cardRecord{Holder: "Jane", Number: "4111-1111-1111-1111"},
+		"named":  CardNumber("4111 1111 1111 1111"),
+		"plain":  CardNumber("not a card"),
+	}
+
+	result := mask.Mask(input).(map[string]interface{})
+	record := result["record"].(map[string]interface{})
+
+	assert.Equal(t, "Jane", record["holder"])
+	assert.Equal(t, "4111********1111", record["number"])
+	assert.Equal(t, "4111********1111", result["named"])
+	assert.Equal(t, CardNumber("not a card"), result["plain"])
+}
+
+func TestStrictMode_MaskInterfaceMarshalError(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithStrictMode(),
+	)
+
+	node := &linkedNode{Card: "4111111111111111"}
+	node.Next = node
+
+	assert.Equal(t, masker.RedactedValue, mask.MaskInterface(node))
+	assert.Equal(t, masker.RedactedValue, mask.MaskInterface(make(chan int)))
+}
@@ -0,0 +1,130 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLuhnValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"visa test card", "4111-1111-1111-1111", true},
+		{"mastercard test card", "5500 0000 0000 0004", true},
+		{"wrong check digit", "4111111111111112", false},
+		{"letters", "4111abcd11111111", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, masker.IsLuhnValid(tt.input))
+		})
+	}
+}
+
+func TestIsCPFValid(t *testing.T) {
+	assert.True(t, masker.IsCPFValid("529.982.247-25"))
+	assert.True(t, masker.IsCPFValid("52998224725"))
+	assert.False(t, masker.IsCPFValid("529.982.247-26"))
+	assert.False(t, masker.IsCPFValid("111.111.111-11"))
+	assert.False(t, masker.IsCPFValid("123"))
+}
+
+func TestIsCNPJValid(t *testing.T) {
+	assert.True(t, masker.IsCNPJValid("11.222.333/0001-81"))
+	assert.False(t, masker.IsCNPJValid("11.222.333/0001-80"))
+	assert.False(t, masker.IsCNPJValid("00.000.000/0000-00"))
+}
+
+func TestIsIBANValid(t *testing.T) {
+	assert.True(t, masker.IsIBANValid("GB82 WEST 1234 5698 7654 32"))
+	assert.True(t, masker.IsIBANValid("DE89370400440532013000"))
+	assert.False(t, masker.IsIBANValid("GB82 WEST 1234 5698 7654 33"))
+	assert.False(t, masker.IsIBANValid("GB82-WEST"))
+}
+
+func TestDateInRange(t *testing.T) {
+	validate := masker.DateInRange(
+		time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2010, 12, 31, 0, 0, 0, 0, time.UTC),
+	)
+
+	assert.True(t, validate("1985-04-12"))
+	assert.True(t, validate("12/04/1985"))
+	assert.False(t, validate("2020-01-01"))
+	assert.False(t, validate("not a date"))
+}
+
+func TestValidate_RejectsBeforeMasking(t *testing.T) {
+	masked := 0
+	mask := masker.NewWithOpts(
+		masker.WithValidatedPattern("card", `\b\d{16}\b`, masker.IsLuhnValid, func(s string) string {
+			masked++
+			return "[CARD]"
+		}),
+	)
+
+	assert.Equal(t, "[CARD]", mask.Mask("4111111111111111"))
+	assert.Equal(t, "4111111111111112", mask.Mask("4111111111111112"))
+	assert.Equal(t, 1, masked, "MaskFunc must only run for validated candidates")
+}
+
+func TestValidate_RejectedCandidateLetsOtherPatternWin(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithPatterns([]masker.Pattern{
+			{
+				Name:     "card",
+				Regex:    `\d{16}`,
+				Priority: 10,
+				Validate: masker.IsLuhnValid,
+				MaskFunc: func(s string) string { return "[CARD]" },
+			},
+			{
+				Name:     "account",
+				Regex:    `\d{16}`,
+				MaskFunc: func(s string) string { return "[ACCOUNT]" },
+			},
+		}),
+		masker.WithOverlapMode(masker.OverlapPriority),
+	)
+
+	assert.Equal(t, "[CARD]", mask.Mask("4111111111111111"))
+	assert.Equal(t, "[ACCOUNT]", mask.Mask("4111111111111112"))
+}
+
+func TestMaskWithReport(t *testing.T) {
+	mask := masker.NewWithOpts(
+		masker.WithValidatedPattern("card", `\b\d{16}\b`, masker.IsLuhnValid, func(s string) string {
+			return "[CARD]"
+		}),
+	)
+
+	input := map[string]interface{}{
+		"payment": map[string]interface{}{
+			"cards": []interface{}{"4111111111111111", "4111111111111112"},
+		},
+	}
+
+	result, report := mask.(masker.ReportingMasker).MaskWithReport(input)
+	cards := result.(map[string]interface{})["payment"].(map[string]interface{})["cards"].([]interface{})
+
+	assert.Equal(t, "[CARD]", cards[0])
+	assert.Equal(t, "4111111111111112", cards[1])
+
+	masked := report.Masked()
+	rejected := report.Rejected()
+	if assert.Len(t, masked, 1) && assert.Len(t, rejected, 1) {
+		assert.Equal(t, "card", masked[0].Pattern)
+		assert.Equal(t, "payment.cards[0]", masked[0].Path)
+		assert.Equal(t, 0, masked[0].Start)
+		assert.Equal(t, 16, masked[0].End)
+		assert.Equal(t, "payment.cards[1]", rejected[0].Path)
+		assert.Equal(t, "validation failed", rejected[0].Reason)
+	}
+}
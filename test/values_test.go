@@ -0,0 +1,90 @@
+package test
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+	"time"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+)
+
+type cardHolder struct {
+	card string
+}
+
+func (c cardHolder) String() string {
+	return "holder with card " + c.card
+}
+
+type paymentError struct {
+	Code string
+}
+
+func (e *paymentError) Error() string {
+	return "payment " + e.Code + " declined for 4111 1111 1111 1111"
+}
+
+func TestMaskBytes(t *testing.T) {
+	input := []byte("card=4111-1111-1111-1111")
+
+	result := masker.MaskData(input)
+
+	assert.Equal(t, []byte("card=4111********1111"), result)
+	assert.Equal(t, []byte("card=4111-1111-1111-1111"), input, "input must not be modified")
+}
+
+func TestMaskBytes_NoMatchReturnsInput(t *testing.T) {
+	input := []byte("nothing to see here")
+
+	result := masker.MaskData(input).([]byte)
+
+	assert.Equal(t, &input[0], &result[0])
+}
+
+func TestMaskBytes_SensitiveKey(t *testing.T) {
+	mask := masker.NewWithOpts(masker.WithSensitiveKeys("secret"))
+
+	result := mask.Mask(map[string]interface{}{"secret": []byte("abc")}).(map[string]interface{})
+
+	assert.Equal(t, []byte("[REDACTED]"), result["secret"])
+}
+
+func TestMaskError_PreservesChain(t *testing.T) {
+	inner := &paymentError{Code: "51"}
+	err := fmt.Errorf("charge failed: %w", inner)
+
+	result := masker.MaskData(err).(error)
+
+	assert.Equal(t, "charge failed: payment 51 declined for 4111********1111", result.Error())
+	assert.True(t, errors.Is(result, inner))
+	var target *paymentError
+	assert.True(t, errors.As(result, &target))
+	assert.Equal(t, "51", target.Code)
+}
+
+func TestMaskError_UnchangedReturnsOriginal(t *testing.T) {
+	result := masker.MaskData(fs.ErrNotExist)
+
+	assert.Same(t, fs.ErrNotExist, result)
+}
+
+func TestMaskStringer(t *testing.T) {
+	holder := cardHolder{card: "4111111111111111"}
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		assert.Equal(t, holder, masker.MaskData(holder))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		mask := masker.NewWithOpts(
+			masker.WithPatterns(masker.DefaultPatterns()),
+			masker.WithStringerMasking(),
+		)
+		assert.Equal(t, "holder with card 4111********1111", mask.Mask(holder))
+		assert.Equal(t, when, mask.Mask(when), "unchanged String output keeps the original value")
+	})
+}
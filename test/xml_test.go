@@ -0,0 +1,80 @@
+package test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	masker "github.com/gocariq/go-sensitive/masker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const soapPayment = `<?xml version="1.0" encoding="UTF-8"?>
+<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:pay="urn:bank:payments">
+  <soap:Header>
+    <pay:Auth user="api" password="hunter2"/>
+  </soap:Header>
+  <soap:Body>
+    <!-- retry for card 4111-1111-1111-1111 -->
+    <pay:Charge currency="BRL" ref="4111 1111 1111 1111">
+      <pay:CardNumber>4111111111111111</pay:CardNumber>
+      <pay:Holder>Jane &amp; John</pay:Holder>
+      <pay:Note>paid with 5500-0000-0000-0004</pay:Note>
+      <pay:Password><![CDATA[s3cr3t<>]]></pay:Password>
+    </pay:Charge>
+  </soap:Body>
+</soap:Envelope>`
+
+func TestMaskXML(t *testing.T) {
+	var out bytes.Buffer
+
+	err := masker.MaskXML(strings.NewReader(soapPayment), &out,
+		masker.WithPatterns(masker.DefaultPatterns()),
+		masker.WithSensitiveKeys("CardNumber", "password"),
+	)
+	require.NoError(t, err)
+	result := out.String()
+
+	assert.Contains(t, result, `<?xml version="1.0" encoding="UTF-8"?>`)
+	assert.Contains(t, result, `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/" xmlns:pay="urn:bank:payments">`)
+	assert.Contains(t, result, `<pay:Auth user="api" password="[REDACTED]"></pay:Auth>`)
+	assert.Contains(t, result, `<!-- retry for card 4111********1111 -->`)
+	assert.Contains(t, result, `<pay:Charge currency="BRL" ref="4111********1111">`)
+	assert.Contains(t, result, `<pay:CardNumber>[REDACTED]</pay:CardNumber>`)
+	assert.Contains(t, result, `<pay:Holder>Jane &amp; John</pay:Holder>`)
+	assert.Contains(t, result, `<pay:Note>paid with 5500********0004</pay:Note>`)
+	assert.Contains(t, result, `<pay:Password>[REDACTED]</pay:Password>`)
+	assert.Contains(t, result, "\n      <pay:Holder>", "indentation is preserved")
+	assert.NotContains(t, result, "s3cr3t")
+	assert.NotContains(t, result, "hunter2")
+}
+
+func TestMaskXML_SensitiveElementChildren(t *testing.T) {
+	input := `<Payment><Card><Number>4111111111111111</Number><Brand>visa</Brand></Card><Amount>10</Amount></Payment>`
+	var out bytes.Buffer
+
+	mask := masker.NewWithOpts(masker.WithSensitiveKeys("card")).(*masker.DefaultMasker)
+	require.NoError(t, mask.MaskXML(strings.NewReader(input), &out))
+
+	assert.Equal(t,
+		`<Payment><Card><Number>[REDACTED]</Number><Brand>[REDACTED]</Brand></Card><Amount>10</Amount></Payment>`,
+		out.String())
+}
+
+func TestMaskXML_DefaultSensitiveKeys(t *testing.T) {
+	var out bytes.Buffer
+
+	err := masker.MaskXML(strings.NewReader(`<Login user="joe" password="x"><Password>hunter2</Password></Login>`), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, `<Login user="joe" password="[REDACTED]"><Password>[REDACTED]</Password></Login>`, out.String())
+}
+
+func TestMaskXML_MalformedInput(t *testing.T) {
+	var out bytes.Buffer
+
+	err := masker.MaskXML(strings.NewReader(`<a><b>unterminated`), &out)
+
+	assert.Error(t, err)
+}